@@ -6,224 +6,577 @@
 package config
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
 	"strings"
 
 	"github.com/creasty/defaults"
-	"github.com/stretchr/testify/assert/yaml"
 	"github.com/zauberhaus/config/pkg/env"
 	"github.com/zauberhaus/config/pkg/flags"
 	"github.com/zauberhaus/config/pkg/index"
+	"github.com/zauberhaus/config/pkg/merge"
+	"github.com/zauberhaus/config/pkg/validate"
 	"github.com/zauberhaus/lookup"
 )
 
-var (
-	extensions = []Extension{
-		{
-			Name:     ".json",
-			FileType: JSON,
-		},
-		{
-			Name:     ".yaml",
-			FileType: YAML,
-		},
-		{
-			Name:     ".yml",
-			FileType: YAML,
-		},
-	}
-)
+const profileKey = "PROFILE"
+
+type configFile struct {
+	Name     string
+	FileType FileType
+	Data     []byte
+	Includes []string
+
+	// rewritten is set if Data differs from the file content
+	rewritten bool
+	// read returns the data of a file from memory or a reader
+	read func(limit int64) ([]byte, error)
+}
 
 func Load[P ~*T, T any](options ...Option) (P, string, error) {
+	cfg, _, name, err := load[T](options...)
+	return cfg, name, err
+}
+
+// LoadFiles works like Load, but returns all files which contributed to the
+// configuration in the order they were applied.
+func LoadFiles[P ~*T, T any](options ...Option) (P, []string, error) {
+	cfg, files, _, err := load[T](options...)
+
+	names := make([]string, 0, len(files))
+	for _, f := range files {
+		names = append(names, f.Includes...)
+		names = append(names, f.Name)
+	}
+
+	return cfg, names, err
+}
+
+func load[T any](options ...Option) (*T, []configFile, string, error) {
 	o := &ConfigOptions{}
 	for _, opt := range options {
 		opt.Set(o)
 	}
 
-	if o.File == "" {
-		f, ft, err := findConfigFile(o)
+	stages, err := precedence(o)
+	if err != nil {
+		return nil, nil, o.File, err
+	}
+
+	// the index is needed to check the permissions of files with secrets
+	if len(o.Index) == 0 {
+		d, err := index.New[T](o.Replacer)
 		if err != nil {
-			return nil, "", err
+			return nil, nil, "", err
 		}
 
-		o.File = f
-		o.FileType = ft
+		o.Index = d
+	}
 
-	} else {
-		if strings.Contains(o.File, "..") {
-			return nil, "", fmt.Errorf("path traversal attempt: '%s'", o.File)
-		}
+	var files []configFile
 
-		o.FileType = GetFileType(o.File, extensions...)
+	if slices.Contains(stages, FileStage) {
+		var name string
+
+		files, name, err = collectFiles(o)
+		if err != nil {
+			return nil, files, name, err
+		}
 	}
 
 	np := *new(T)
 	cfg := &np
 
-	err := defaults.Set(cfg)
+	err = defaults.Set(cfg)
 	if err != nil {
-		return nil, "", err
+		return nil, files, "", err
 	}
 
-	if len(o.Index) == 0 {
-		d, err := index.New[T](o.Replacer)
+	err = afterDefaults(cfg)
+	if err != nil {
+		return nil, files, "", err
+	}
+
+	defer o.report.redact(o.Index)
+
+	err = o.report.addDefaults(cfg, o.Index, false)
+	if err != nil {
+		return nil, files, "", err
+	}
+
+	for _, stage := range stages {
+		switch stage {
+		case DefaultsStage:
+			// the default values are set before all other sources
+		case FileStage:
+			if len(files) > 0 {
+				name, err := loadFiles(cfg, files, o.Index, o.report)
+				if err != nil {
+					return nil, files, name, err
+				}
+
+				// defaults of struct pointers created for a file
+				err = o.report.addDefaults(cfg, o.Index, false)
+				if err != nil {
+					return nil, files, "", err
+				}
+			}
+
+			err = afterFile(cfg)
+			if err != nil {
+				return nil, files, o.File, err
+			}
+		case EnvStage:
+			if len(o.Name) > 0 {
+				opts := []env.Option{env.WithName(o.Name), env.WithStrict(o.Strict), env.WithIndex(o.Index), env.WithIgnore(profileKey, EncryptionKeyEnv, EncryptionKeyFileEnv)}
+				if o.report != nil {
+					opts = append(opts, env.WithObserver(o.report.observer(EnvOrigin)))
+				}
+
+				_, err = env.Set(cfg, opts...)
+				if err != nil {
+					return nil, files, o.File, err
+				}
+			}
+		case FlagsStage:
+			if o.Flags != nil {
+				opts := []flags.Option{flags.WithIndex(o.Index)}
+				if o.report != nil {
+					opts = append(opts, flags.WithObserver(o.report.observer(FlagOrigin)))
+				}
+
+				err = flags.SetFlags(cfg, o.Flags, opts...)
+				if err != nil {
+					return nil, files, o.File, err
+				}
+			}
+		default:
+			err = applySource(o, cfg, stage)
+			if err != nil {
+				return nil, files, o.File, err
+			}
+		}
+	}
+
+	// fields which no source set keep their zero value
+	err = o.report.addDefaults(cfg, o.Index, true)
+	if err != nil {
+		return nil, files, o.File, err
+	}
+
+	err = resolveSecrets(o, cfg)
+	if err != nil {
+		return nil, files, o.File, err
+	}
+
+	err = afterLoad(cfg)
+	if err != nil {
+		return nil, files, o.File, err
+	}
+
+	if !o.SkipValidation {
+		opts := []validate.Option{validate.WithIndex(o.Index), validate.WithFlags(o.Flags)}
+		if len(o.Name) > 0 {
+			opts = append(opts, validate.WithName(o.Name))
+		}
+
+		err = validate.Validate(cfg, opts...)
 		if err != nil {
-			return *new(P), "", err
+			return nil, files, o.File, err
 		}
+	}
 
-		o.Index = d
+	return cfg, files, o.File, nil
+}
+
+// applySource applies the custom source of a stage.
+func applySource(o *ConfigOptions, cfg any, stage Stage) error {
+	ctx := o.Context
+	if ctx == nil {
+		ctx = context.Background()
 	}
 
-	optional := []string{}
+	ctx = context.WithValue(ctx, strictKey{}, o.Strict)
 
-	for _, v := range o.Index {
-		if v.Optional {
-			optional = append(optional, v.Path)
+	for _, src := range o.Sources {
+		if Stage(src.Name()) != stage {
+			continue
+		}
+
+		snapshot, err := o.report.snapshot(cfg, o.Index)
+		if err != nil {
+			return err
+		}
+
+		err = src.Apply(ctx, cfg, o.Index)
+		if err != nil {
+			return fmt.Errorf("source %s: %w", src.Name(), err)
+		}
+
+		return o.report.addChanges(cfg, o.Index, snapshot, src.Name())
+	}
+
+	return nil
+}
+
+// collectFiles returns the config files with their profile overlays,
+// drop-ins and includes.
+func collectFiles(o *ConfigOptions) ([]configFile, string, error) {
+	files, err := configFiles(o)
+	if err != nil {
+		return nil, o.File, err
+	}
+
+	// deleting a signed file must not skip the verification
+	if len(files) == 0 && len(o.PublicKeys) > 0 {
+		return nil, o.File, &SignatureError{Missing: true}
+	}
+
+	if len(files) > 0 {
+		o.File = files[len(files)-1].Name
+		o.FileType = files[len(files)-1].FileType
+	}
+
+	if o.Profile == "" && o.Name != "" {
+		o.Profile = os.Getenv(env.Prefix(o.Name) + profileKey)
+	}
+
+	if o.Profile != "" {
+		files, err = profileFiles(o, files)
+		if err != nil {
+			return files, o.File, err
 		}
 	}
 
-	if len(o.File) > 0 {
-		data, err := os.ReadFile(o.File)
+	dropIns, err := dropInFiles(o)
+	if err != nil {
+		return files, o.File, err
+	}
+
+	files = append(files, dropIns...)
+
+	return expandFiles(o, files)
+}
+
+// configFiles returns the files to load, ordered from lowest to highest priority.
+func configFiles(o *ConfigOptions) ([]configFile, error) {
+	names := slices.Clone(o.Files)
+	if o.File != "" {
+		names = append(names, o.File)
+	}
+
+	if len(names) == 0 && len(o.inputs) == 0 {
+		files, err := findConfigFiles(o)
 		if err != nil {
-			return nil, o.File, err
+			return nil, err
 		}
 
-		switch o.FileType {
-		case JSON:
-			err = json.Unmarshal(data, cfg)
-		case YAML:
-			err = yaml.Unmarshal(data, cfg)
-		default:
-			return nil, o.File, fmt.Errorf("unknown file type: %s (%v)", o.File, o.FileType)
+		if !o.Layered && len(files) > 1 {
+			files = files[:1]
 		}
 
+		slices.Reverse(files)
+
+		return files, nil
+	}
+
+	files := make([]configFile, 0, len(names)+len(o.inputs))
+
+	for _, name := range names {
+		if name == Stdin {
+			files = append(files, stdin)
+			continue
+		}
+
+		if _, err := checkPath(o, name); err != nil {
+			return nil, err
+		}
+
+		files = append(files, configFile{
+			Name:     name,
+			FileType: fileType(o, name),
+		})
+	}
+
+	return append(files, o.inputs...), nil
+}
+
+// profileFiles adds the profile overlays like config.prod.yaml after the
+// matching config files.
+func profileFiles(o *ConfigOptions, files []configFile) ([]configFile, error) {
+	if strings.ContainsAny(o.Profile, `/\`) || strings.Contains(o.Profile, "..") {
+		return nil, fmt.Errorf("invalid profile: '%s'", o.Profile)
+	}
+
+	result := make([]configFile, 0, len(files)*2)
+
+	for _, f := range files {
+		result = append(result, f)
+
+		if f.read != nil {
+			continue
+		}
+
+		filename := filepath.Base(f.Name)
+		base := strings.TrimSuffix(filename, filepath.Ext(filename)) + "." + o.Profile
+
+		entries, err := readDir(o, dirPath(o, f.Name))
 		if err != nil {
-			return nil, o.File, err
+			continue
 		}
 
-		// set default values for struct pointer if set by config file
-		if len(optional) > 0 {
-			var changed []string
+		for _, e := range entries {
+			filename := e.Name()
 
-			for _, v := range optional {
-				ok, err := lookup.Exists(cfg, v)
-				if err != nil {
-					return nil, "", err
-				}
+			if e.IsDir() || strings.TrimSuffix(filename, filepath.Ext(filename)) != base {
+				continue
+			}
 
-				if ok {
-					changed = append(changed, v)
-				}
+			ft := fileType(o, filename)
+			if ft == UnknownFileType {
+				continue
 			}
 
-			// set defaults and reload config file
-			if len(changed) > 0 {
-				np := *new(T)
-				tmp := &np
+			result = append(result, configFile{
+				Name:     joinPath(o, dirPath(o, f.Name), filename),
+				FileType: ft,
+			})
+		}
+	}
 
-				err = defaults.Set(tmp)
-				if err != nil {
-					return nil, "", err
-				}
+	return result, nil
+}
 
-				for _, v := range changed {
-					_, err := lookup.Create(tmp, v)
-					if err != nil {
-						return nil, "", err
-					}
-				}
+// dropInFiles returns the supported files of the drop-in directory in
+// lexical order.
+func dropInFiles(o *ConfigOptions) ([]configFile, error) {
+	if o.DropInDir == "" {
+		return nil, nil
+	}
 
-				switch o.FileType {
-				case JSON:
-					err = json.Unmarshal(data, tmp)
-				case YAML:
-					err = yaml.Unmarshal(data, tmp)
-				default:
-					return nil, o.File, fmt.Errorf("unknown file type: %s (%v)", o.File, o.FileType)
-				}
+	dir, err := checkPath(o, o.DropInDir)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := readDir(o, dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	var files []configFile
+
+	for _, e := range entries {
+		filename := e.Name()
+
+		if e.IsDir() || filename[0] == '.' {
+			continue
+		}
+
+		ft := fileType(o, filename)
+		if ft == UnknownFileType {
+			continue
+		}
+
+		files = append(files, configFile{
+			Name:     joinPath(o, dir, filename),
+			FileType: ft,
+		})
+	}
+
+	return files, nil
+}
+
+// loadFiles decodes the files one after another into cfg. Nested structs and
+// maps are merged, slices and map entries are replaced by later files unless
+// the field has a merge strategy.
+func loadFiles[T any](cfg *T, files []configFile, idx index.Index, r Report) (string, error) {
+	optional := []string{}
+	strategies := map[string]merge.Strategy{}
+
+	for _, v := range idx {
+		if v.Optional {
+			optional = append(optional, v.Path)
+		}
+
+		if v.Merge != merge.Default && v.Type != nil && !strings.Contains(v.Path, "[]") {
+			switch v.Type.Kind() {
+			case reflect.Slice, reflect.Map:
+				strategies[v.Path] = v.Merge
+			}
+		}
+	}
+
+	sort.Strings(optional)
+
+	// set default values for struct pointer if set by a config file
+	if len(optional) > 0 {
+		tmp := new(T)
+
+		for _, f := range files {
+			err := decode(f.Name, f.FileType, f.Data, tmp)
+			if err != nil {
+				return f.Name, err
+			}
+		}
+
+		for _, v := range optional {
+			ok, err := lookup.Exists(tmp, v)
+			if err != nil {
+				return "", err
+			}
+
+			if !ok {
+				continue
+			}
+
+			ok, err = lookup.Exists(cfg, v)
+			if err != nil {
+				return "", err
+			}
 
+			if !ok {
+				_, err := lookup.Create(cfg, v)
 				if err != nil {
-					return nil, o.File, err
+					return "", err
 				}
-
-				cfg = tmp
 			}
 		}
 	}
 
-	if len(o.Name) > 0 {
-		_, err = env.Set(cfg, env.WithName(o.Name), env.WithStrict(o.Strict), env.WithIndex(o.Index))
+	for _, f := range files {
+		err := decodeMerged(cfg, f, strategies)
+		if err != nil {
+			return f.Name, err
+		}
+
+		err = r.addFile(cfg, f, idx)
 		if err != nil {
-			return nil, o.File, err
+			return f.Name, err
 		}
 	}
 
-	if o.Flags != nil {
-		err = flags.SetFlags(cfg, o.Flags)
+	return "", nil
+}
+
+// decodeMerged decodes a file and combines the values of fields with a merge
+// strategy with the values set before.
+func decodeMerged(cfg any, f configFile, strategies map[string]merge.Strategy) error {
+	old := map[string]any{}
+
+	for path := range strategies {
+		ok, err := lookup.Exists(cfg, path)
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			continue
+		}
+
+		val, err := lookup.Get(cfg, path)
 		if err != nil {
-			return nil, o.File, err
+			return err
 		}
+
+		old[path] = merge.Clone(val)
+
+		_, err = lookup.Set(cfg, path, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := decode(f.Name, f.FileType, f.Data, cfg)
+	if err != nil {
+		return err
 	}
 
-	return cfg, o.File, nil
+	for path, val := range old {
+		new, err := lookup.Get(cfg, path)
+		if err != nil {
+			return err
+		}
+
+		if new != nil && !reflect.ValueOf(new).IsNil() {
+			val = merge.Combine(strategies[path], val, new)
+		}
+
+		_, err = lookup.Set(cfg, path, val)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
-func findConfigFile(o *ConfigOptions) (string, FileType, error) {
+// findConfigFiles returns all matching config files, ordered from highest to
+// lowest priority.
+func findConfigFiles(o *ConfigOptions) ([]configFile, error) {
 	if len(o.Extensions) == 0 {
-		o.Extensions = extensions
+		o.Extensions = Extensions()
 	}
 
-	if o.Name == "" {
+	named := o.Name != ""
+	if !named {
 		o.Name = "config"
 	}
 
 	tmp := os.Getenv("CONFIG")
-	if tmp != "" {
-		if strings.Contains(tmp, "..") {
-			return "", UnknownFileType, fmt.Errorf("path traversal attempt: '%s'", tmp)
-		}
-		fp := filepath.Clean(tmp)
+	if tmp == Stdin {
+		return []configFile{stdin}, nil
+	}
 
-		name, err := filepath.Abs(fp)
+	if tmp != "" {
+		name, err := checkPath(o, tmp)
 		if err != nil {
-			return "", UnknownFileType, fmt.Errorf("invalid path '%s': %w", fp, err)
+			return nil, err
 		}
 
 		ft := GetFileType(name, o.Extensions...)
 		if ft == UnknownFileType {
-			return "", UnknownFileType, fmt.Errorf("unknown file type: %s", name)
+			return nil, fmt.Errorf("unknown file type: %s", name)
 		}
 
-		return name, ft, nil
-	}
-
-	cwd, err := os.Getwd()
-	if err != nil {
-		return "", UnknownFileType, fmt.Errorf("get current index failed: %v", err)
+		return []configFile{{Name: name, FileType: ft}}, nil
 	}
 
-	paths := append(o.Paths, cwd)
-
-	// Find home index.
-	home, err := os.UserHomeDir()
+	paths, err := searchPaths(o, named)
 	if err != nil {
-		return "", UnknownFileType, fmt.Errorf("get homedir failed: %v", err)
+		return nil, err
 	}
 
-	paths = append(paths, home)
+	var files []configFile
+	visited := map[string]bool{}
 
 	for _, p := range paths {
-		fp, err := filepath.Abs(p)
+		fp, err := searchDir(o, p.dir)
 		if err != nil {
-			return "", UnknownFileType, fmt.Errorf("invalid path '%s': %w", fp, err)
+			return nil, err
 		}
 
-		fp = filepath.Clean(fp)
+		if visited[fp] {
+			continue
+		}
 
-		entries, err := os.ReadDir(fp)
+		// discovered files outside of the roots are skipped
+		if _, _, ok := findRoot(o, fp, false); len(o.Roots) > 0 && o.FS == nil && !ok {
+			continue
+		}
+
+		visited[fp] = true
+
+		entries, err := readDir(o, fp)
 		if err != nil {
 			continue
 		}
@@ -231,10 +584,6 @@ func findConfigFile(o *ConfigOptions) (string, FileType, error) {
 		for _, e := range entries {
 			filename := e.Name()
 
-			if strings.Contains(filename, "..") {
-				continue
-			}
-
 			if e.IsDir() || len(filename) < 4 || filename[0] == '.' {
 				continue
 			}
@@ -245,7 +594,7 @@ func findConfigFile(o *ConfigOptions) (string, FileType, error) {
 			}
 
 			base := strings.TrimSuffix(filename, ext)
-			if base != o.Name {
+			if !slices.Contains(p.names, base) {
 				continue
 			}
 
@@ -254,16 +603,34 @@ func findConfigFile(o *ConfigOptions) (string, FileType, error) {
 				continue
 			}
 
-			return filepath.Join(fp, filename), ft, nil
+			files = append(files, configFile{
+				Name:     joinPath(o, fp, filename),
+				FileType: ft,
+			})
 		}
 	}
 
-	return "", UnknownFileType, nil
+	return files, nil
+}
+
+// searchDir returns the clean path of a search directory, for a file system
+// set by WithFS relative to its root.
+func searchDir(o *ConfigOptions, dir string) (string, error) {
+	if o.FS != nil {
+		return checkPath(o, dir)
+	}
+
+	fp, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("invalid path '%s': %w", dir, err)
+	}
+
+	return filepath.Clean(fp), nil
 }
 
 func GetFileType(name string, ext ...Extension) FileType {
 	if len(ext) == 0 {
-		ext = extensions
+		ext = Extensions()
 	}
 
 	for _, v := range ext {
@@ -274,3 +641,22 @@ func GetFileType(name string, ext ...Extension) FileType {
 
 	return UnknownFileType
 }
+
+// fileType returns the file type for the extensions of the options and falls
+// back to the registered formats.
+func fileType(o *ConfigOptions, name string) FileType {
+	ft := GetFileType(name, o.Extensions...)
+	if ft == UnknownFileType && len(o.Extensions) > 0 {
+		ft = GetFileType(name)
+	}
+
+	return ft
+}
+
+func decode(name string, ft FileType, data []byte, v any) error {
+	if _, ok := GetFormat(ft); !ok {
+		return fmt.Errorf("unknown file type: %s (%v)", name, ft)
+	}
+
+	return ft.Decode(data, v)
+}
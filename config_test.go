@@ -17,6 +17,7 @@ import (
 	"github.com/zauberhaus/config"
 	"github.com/zauberhaus/config/pkg/flags"
 	"github.com/zauberhaus/config/pkg/index"
+	"github.com/zauberhaus/config/pkg/validate"
 )
 
 type TestLoadConfig struct {
@@ -97,6 +98,33 @@ sub:
 		assert.Equal(t, "json-sub", cfg.Sub.Name)
 	})
 
+	t.Run("load from toml file in search path", func(t *testing.T) {
+		tomlContent := `
+Host = "toml.host.com"
+port = 9092
+slice = ["a", "b"]
+
+[sub]
+name = "toml-sub"
+`
+		tomlFile := filepath.Join(tempDir, "toml-app.toml")
+		err := os.WriteFile(tomlFile, []byte(tomlContent), 0644)
+		require.NoError(t, err)
+
+		require.NoError(t, os.Chdir(tempDir))
+
+		cfg, f, err := config.Load[*TestLoadConfig](config.WithName("toml-app"))
+		require.NoError(t, err)
+		assert.Equal(t, tomlFile, f)
+
+		assert.Equal(t, "toml.host.com", cfg.Host)
+		assert.Equal(t, 9092, cfg.Port)
+		assert.True(t, cfg.Enabled) // From default
+		assert.Equal(t, "toml-sub", cfg.Sub.Name)
+		assert.Equal(t, []string{"a", "b"}, cfg.Slice)
+		assert.Nil(t, cfg.Sub2)
+	})
+
 	t.Run("load from yaml file in home index", func(t *testing.T) {
 		// Change to a index with no config file to ensure home dir is used
 		require.NoError(t, os.Chdir(t.TempDir()))
@@ -210,6 +238,22 @@ sub:
 			}
 		})
 
+		t.Run("toml config file", func(t *testing.T) {
+			file := filepath.Join(tempDir, "sub2toml.toml")
+			content := "[sub2]\nname = \"new name\"\n"
+			err = os.WriteFile(file, []byte(content), 0644)
+			require.NoError(t, err)
+
+			cfg, f, err := config.Load[*TestLoadConfig](config.WithName("sub2toml"))
+			require.NoError(t, err)
+			assert.Equal(t, file, f)
+
+			if assert.NotNil(t, cfg.Sub2) {
+				assert.Equal(t, "new name", cfg.Sub2.Name)
+				assert.Equal(t, "sub2-default", cfg.Sub2.Other)
+			}
+		})
+
 		t.Run("not set", func(t *testing.T) {
 			cfg, f, err := config.Load[*TestLoadConfig]()
 			require.NoError(t, err)
@@ -507,6 +551,7 @@ func TestGetFileType(t *testing.T) {
 		{"json", "config.json", nil, config.JSON},
 		{"yaml", "config.yaml", nil, config.YAML},
 		{"yml", "config.yml", nil, config.YAML},
+		{"toml", "config.toml", nil, config.TOML},
 		{"unknown", "config.txt", nil, config.UnknownFileType},
 		{"no extension", "config", nil, config.UnknownFileType},
 		{"custom json", "config.jso", []config.Extension{{Name: ".jso", FileType: config.JSON}}, config.JSON},
@@ -520,3 +565,527 @@ func TestGetFileType(t *testing.T) {
 		})
 	}
 }
+
+type TestLayeredConfig struct {
+	Host   string `default:"localhost"`
+	Port   int    `default:"8080"`
+	Server struct {
+		Name    string
+		Timeout int `default:"30"`
+	}
+	Tags  map[string]string
+	Hosts []string
+	TLS   *struct {
+		Cert string
+		Key  string `default:"key.pem"`
+	}
+}
+
+func TestLoad_Layered(t *testing.T) {
+	tempDir := t.TempDir()
+	homeDir := t.TempDir()
+	t.Setenv("HOME", homeDir)
+
+	base := filepath.Join(tempDir, "base.yaml")
+	err := os.WriteFile(base, []byte(`
+host: base.host.com
+server:
+  name: base
+tags:
+  a: "1"
+  b: "2"
+hosts:
+  - a
+  - b
+`), 0644)
+	require.NoError(t, err)
+
+	override := filepath.Join(tempDir, "override.json")
+	err = os.WriteFile(override, []byte(`{"port": 9090, "server": {"timeout": 60}, "tags": {"b": "3", "c": "4"}, "hosts": ["c"], "tls": {"cert": "cert.pem"}}`), 0644)
+	require.NoError(t, err)
+
+	t.Run("with files", func(t *testing.T) {
+		cfg, f, err := config.Load[*TestLayeredConfig](config.WithFiles(base, override))
+		require.NoError(t, err)
+		assert.Equal(t, override, f)
+
+		assert.Equal(t, "base.host.com", cfg.Host)
+		assert.Equal(t, 9090, cfg.Port)
+		assert.Equal(t, "base", cfg.Server.Name)
+		assert.Equal(t, 60, cfg.Server.Timeout)
+		assert.Equal(t, map[string]string{"a": "1", "b": "3", "c": "4"}, cfg.Tags)
+		assert.Equal(t, []string{"c"}, cfg.Hosts)
+
+		if assert.NotNil(t, cfg.TLS) {
+			assert.Equal(t, "cert.pem", cfg.TLS.Cert)
+			assert.Equal(t, "key.pem", cfg.TLS.Key)
+		}
+	})
+
+	t.Run("reverse order", func(t *testing.T) {
+		cfg, f, err := config.Load[*TestLayeredConfig](config.WithFiles(override, base))
+		require.NoError(t, err)
+		assert.Equal(t, base, f)
+
+		assert.Equal(t, 60, cfg.Server.Timeout)
+		assert.Equal(t, map[string]string{"a": "1", "b": "2", "c": "4"}, cfg.Tags)
+		assert.Equal(t, []string{"a", "b"}, cfg.Hosts)
+	})
+
+	t.Run("with files and file", func(t *testing.T) {
+		cfg, f, err := config.Load[*TestLayeredConfig](config.WithFile(base), config.WithFiles(override))
+		require.NoError(t, err)
+		assert.Equal(t, base, f)
+		assert.Equal(t, []string{"a", "b"}, cfg.Hosts)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		missing := filepath.Join(tempDir, "missing.yaml")
+		_, f, err := config.Load[*TestLayeredConfig](config.WithFiles(base, missing))
+		assert.Error(t, err)
+		assert.True(t, os.IsNotExist(err))
+		assert.Equal(t, missing, f)
+	})
+
+	t.Run("invalid file", func(t *testing.T) {
+		invalid := filepath.Join(tempDir, "invalid.json")
+		require.NoError(t, os.WriteFile(invalid, []byte(`{"host": `), 0644))
+
+		_, f, err := config.Load[*TestLayeredConfig](config.WithFiles(invalid, base))
+		assert.Error(t, err)
+		assert.Equal(t, invalid, f)
+	})
+
+	t.Run("path traversal", func(t *testing.T) {
+		_, _, err := config.Load[*TestLayeredConfig](config.WithFiles(base, "../config.yaml"))
+		assert.ErrorContains(t, err, "path traversal attempt")
+	})
+
+	t.Run("discovery", func(t *testing.T) {
+		cwd := t.TempDir()
+		custom := t.TempDir()
+
+		homeFile := filepath.Join(homeDir, "layered.yaml")
+		require.NoError(t, os.WriteFile(homeFile, []byte("host: home.host.com\nport: 1000\nserver:\n  name: home\n"), 0644))
+
+		cwdFile := filepath.Join(cwd, "layered.yaml")
+		require.NoError(t, os.WriteFile(cwdFile, []byte("port: 2000\n"), 0644))
+
+		customFile := filepath.Join(custom, "layered.json")
+		require.NoError(t, os.WriteFile(customFile, []byte(`{"server": {"name": "custom"}}`), 0644))
+
+		require.NoError(t, os.Chdir(cwd))
+
+		t.Run("first match", func(t *testing.T) {
+			cfg, f, err := config.Load[*TestLayeredConfig](config.WithName("layered"), config.WithPaths(custom))
+			require.NoError(t, err)
+			assert.Equal(t, customFile, f)
+
+			assert.Equal(t, "localhost", cfg.Host)
+			assert.Equal(t, 8080, cfg.Port)
+			assert.Equal(t, "custom", cfg.Server.Name)
+		})
+
+		t.Run("layered", func(t *testing.T) {
+			cfg, f, err := config.Load[*TestLayeredConfig](config.WithName("layered"), config.WithPaths(custom, cwd), config.Layered)
+			require.NoError(t, err)
+			assert.Equal(t, customFile, f)
+
+			assert.Equal(t, "home.host.com", cfg.Host)
+			assert.Equal(t, 2000, cfg.Port)
+			assert.Equal(t, "custom", cfg.Server.Name)
+		})
+	})
+}
+
+func TestLoad_MergeStrategy(t *testing.T) {
+	type Config struct {
+		WhiteList []string          `merge:"append"`
+		Hosts     []string          `merge:"unique"`
+		Ports     []int             `merge:"merge"`
+		Tags      map[string]string `merge:"replace"`
+		Names     []string
+		Sub       *struct {
+			List []string `merge:"append"`
+		}
+	}
+
+	tempDir := t.TempDir()
+
+	base := filepath.Join(tempDir, "base.yaml")
+	err := os.WriteFile(base, []byte(`
+whitelist: [a, b]
+hosts: [a, b]
+ports: [1, 2, 3]
+tags:
+  a: "1"
+names: [a]
+sub:
+  list: [a]
+`), 0644)
+	require.NoError(t, err)
+
+	override := filepath.Join(tempDir, "override.yaml")
+	err = os.WriteFile(override, []byte(`
+whitelist: [c]
+hosts: [b, c]
+ports: [4]
+tags:
+  b: "2"
+sub:
+  list: [b]
+`), 0644)
+	require.NoError(t, err)
+
+	empty := filepath.Join(tempDir, "empty.yaml")
+	err = os.WriteFile(empty, []byte(`names: [b]`), 0644)
+	require.NoError(t, err)
+
+	t.Run("files", func(t *testing.T) {
+		cfg, _, err := config.Load[*Config](config.WithFiles(base, override, empty))
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"a", "b", "c"}, cfg.WhiteList)
+		assert.Equal(t, []string{"a", "b", "c"}, cfg.Hosts)
+		assert.Equal(t, []int{4, 2, 3}, cfg.Ports)
+		assert.Equal(t, map[string]string{"b": "2"}, cfg.Tags)
+		assert.Equal(t, []string{"b"}, cfg.Names)
+
+		if assert.NotNil(t, cfg.Sub) {
+			assert.Equal(t, []string{"a", "b"}, cfg.Sub.List)
+		}
+	})
+
+	t.Run("env and flags", func(t *testing.T) {
+		t.Setenv("MERGE_APP_WHITE_LIST", "d")
+
+		flagSet := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		flagSet.StringSlice("wl", nil, "white list")
+		require.NoError(t, flagSet.Set("wl", "e"))
+
+		fl := flags.NewFlagList(nil)
+		require.NoError(t, fl.BindFlag(flagSet, "WhiteList", flagSet.Lookup("wl")))
+
+		cfg, _, err := config.Load[*Config](
+			config.WithName("merge-app"),
+			config.WithFiles(base, override),
+			config.WithFlags(fl),
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"a", "b", "c", "d", "e"}, cfg.WhiteList)
+	})
+}
+
+func TestLoad_DropInDir(t *testing.T) {
+	tempDir := t.TempDir()
+	confDir := filepath.Join(tempDir, "conf.d")
+	require.NoError(t, os.Mkdir(confDir, 0755))
+	require.NoError(t, os.Mkdir(filepath.Join(confDir, "sub.yaml"), 0755))
+
+	main := filepath.Join(tempDir, "config.yaml")
+	require.NoError(t, os.WriteFile(main, []byte("host: main.host.com\nport: 1000\nserver:\n  name: main\n"), 0644))
+
+	files := map[string]string{
+		"20-port.json":  `{"port": 2000}`,
+		"10-host.yaml":  "host: dropin.host.com\nport: 1500\n",
+		"30-tags.toml":  "[tags]\na = \"1\"\n",
+		"README.md":     "# not a config file",
+		".hidden.yaml":  "host: hidden.host.com\n",
+		"40-name.yml":   "server:\n  name: dropin\n",
+		"50-broken.txt": "host=broken",
+	}
+
+	for k, v := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(confDir, k), []byte(v), 0644))
+	}
+
+	t.Run("load", func(t *testing.T) {
+		cfg, f, err := config.Load[*TestLayeredConfig](config.WithFile(main), config.WithDropInDir(confDir))
+		require.NoError(t, err)
+		assert.Equal(t, main, f)
+
+		assert.Equal(t, "dropin.host.com", cfg.Host)
+		assert.Equal(t, 2000, cfg.Port)
+		assert.Equal(t, "dropin", cfg.Server.Name)
+		assert.Equal(t, map[string]string{"a": "1"}, cfg.Tags)
+	})
+
+	t.Run("load files", func(t *testing.T) {
+		cfg, f, err := config.LoadFiles[*TestLayeredConfig](config.WithFile(main), config.WithDropInDir(confDir))
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			main,
+			filepath.Join(confDir, "10-host.yaml"),
+			filepath.Join(confDir, "20-port.json"),
+			filepath.Join(confDir, "30-tags.toml"),
+			filepath.Join(confDir, "40-name.yml"),
+		}, f)
+		assert.Equal(t, 2000, cfg.Port)
+	})
+
+	t.Run("discovered main file", func(t *testing.T) {
+		require.NoError(t, os.Chdir(tempDir))
+
+		cfg, f, err := config.LoadFiles[*TestLayeredConfig](config.WithDropInDir("conf.d"))
+		require.NoError(t, err)
+		assert.Len(t, f, 5)
+		assert.Equal(t, main, f[0])
+		assert.Equal(t, "dropin.host.com", cfg.Host)
+	})
+
+	t.Run("without main file", func(t *testing.T) {
+		require.NoError(t, os.Chdir(t.TempDir()))
+
+		cfg, f, err := config.LoadFiles[*TestLayeredConfig](config.WithName("no-main"), config.WithDropInDir(confDir))
+		require.NoError(t, err)
+		assert.Len(t, f, 4)
+		assert.Equal(t, "dropin.host.com", cfg.Host)
+	})
+
+	t.Run("missing dir", func(t *testing.T) {
+		cfg, f, err := config.LoadFiles[*TestLayeredConfig](config.WithFile(main), config.WithDropInDir(filepath.Join(tempDir, "missing")))
+		require.NoError(t, err)
+		assert.Equal(t, []string{main}, f)
+		assert.Equal(t, "main.host.com", cfg.Host)
+	})
+
+	t.Run("invalid drop-in file", func(t *testing.T) {
+		invalidDir := t.TempDir()
+		invalid := filepath.Join(invalidDir, "00-invalid.json")
+		require.NoError(t, os.WriteFile(invalid, []byte(`{"port": `), 0644))
+
+		_, f, err := config.Load[*TestLayeredConfig](config.WithFile(main), config.WithDropInDir(invalidDir))
+		assert.Error(t, err)
+		assert.Equal(t, invalid, f)
+	})
+
+	t.Run("path traversal", func(t *testing.T) {
+		_, _, err := config.Load[*TestLayeredConfig](config.WithFile(main), config.WithDropInDir("../conf.d"))
+		assert.ErrorContains(t, err, "path traversal attempt")
+	})
+}
+
+func TestLoad_Profile(t *testing.T) {
+	tempDir := t.TempDir()
+	require.NoError(t, os.Chdir(t.TempDir()))
+
+	main := filepath.Join(tempDir, "profile-app.yaml")
+	require.NoError(t, os.WriteFile(main, []byte("host: main.host.com\nport: 1000\n"), 0644))
+
+	prod := filepath.Join(tempDir, "profile-app.prod.yaml")
+	require.NoError(t, os.WriteFile(prod, []byte("host: prod.host.com\n"), 0644))
+
+	dev := filepath.Join(tempDir, "profile-app.dev.json")
+	require.NoError(t, os.WriteFile(dev, []byte(`{"port": 2000}`), 0644))
+
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "profile-app.test.txt"), []byte("port=3000"), 0644))
+
+	t.Run("with profile", func(t *testing.T) {
+		cfg, f, err := config.LoadFiles[*TestLayeredConfig](
+			config.WithName("profile-app"),
+			config.WithPaths(tempDir),
+			config.WithProfile("prod"),
+		)
+		require.NoError(t, err)
+		assert.Equal(t, []string{main, prod}, f)
+		assert.Equal(t, "prod.host.com", cfg.Host)
+		assert.Equal(t, 1000, cfg.Port)
+	})
+
+	t.Run("from env", func(t *testing.T) {
+		t.Setenv("PROFILE_APP_PROFILE", "dev")
+
+		cfg, f, err := config.Load[*TestLayeredConfig](
+			config.WithName("profile-app"),
+			config.WithPaths(tempDir),
+			config.Strict,
+		)
+		require.NoError(t, err)
+		assert.Equal(t, main, f)
+		assert.Equal(t, "main.host.com", cfg.Host)
+		assert.Equal(t, 2000, cfg.Port)
+	})
+
+	t.Run("option overrides env", func(t *testing.T) {
+		t.Setenv("PROFILE_APP_PROFILE", "dev")
+
+		cfg, _, err := config.Load[*TestLayeredConfig](
+			config.WithName("profile-app"),
+			config.WithPaths(tempDir),
+			config.WithProfile("prod"),
+		)
+		require.NoError(t, err)
+		assert.Equal(t, "prod.host.com", cfg.Host)
+		assert.Equal(t, 1000, cfg.Port)
+	})
+
+	t.Run("with file", func(t *testing.T) {
+		_, f, err := config.LoadFiles[*TestLayeredConfig](config.WithFile(main), config.WithProfile("dev"))
+		require.NoError(t, err)
+		assert.Equal(t, []string{main, dev}, f)
+	})
+
+	t.Run("unknown file type", func(t *testing.T) {
+		_, f, err := config.LoadFiles[*TestLayeredConfig](config.WithFile(main), config.WithProfile("test"))
+		require.NoError(t, err)
+		assert.Equal(t, []string{main}, f)
+	})
+
+	t.Run("missing overlay", func(t *testing.T) {
+		cfg, f, err := config.LoadFiles[*TestLayeredConfig](config.WithFile(main), config.WithProfile("staging"))
+		require.NoError(t, err)
+		assert.Equal(t, []string{main}, f)
+		assert.Equal(t, "main.host.com", cfg.Host)
+	})
+
+	t.Run("invalid profile", func(t *testing.T) {
+		_, _, err := config.Load[*TestLayeredConfig](config.WithFile(main), config.WithProfile("../prod"))
+		assert.ErrorContains(t, err, "invalid profile")
+	})
+}
+
+func TestLoad_Include(t *testing.T) {
+	tempDir := t.TempDir()
+	require.NoError(t, os.Chdir(tempDir))
+
+	shared := filepath.Join(tempDir, "shared")
+	require.NoError(t, os.Mkdir(shared, 0755))
+
+	base := filepath.Join(shared, "base.yaml")
+	require.NoError(t, os.WriteFile(base, []byte("host: base.host.com\nport: 1000\nserver:\n  name: base\n"), 0644))
+
+	common := filepath.Join(tempDir, "common.yaml")
+	require.NoError(t, os.WriteFile(common, []byte("extends: shared/base.yaml\nport: 2000\n"), 0644))
+
+	tls := filepath.Join(shared, "tls.json")
+	require.NoError(t, os.WriteFile(tls, []byte(`{"cert": "tls.pem"}`), 0644))
+
+	server := filepath.Join(shared, "server.yaml")
+	require.NoError(t, os.WriteFile(server, []byte("name: included\ntimeout: 5\n"), 0644))
+
+	t.Run("extends", func(t *testing.T) {
+		file := filepath.Join(tempDir, "extends.yaml")
+		require.NoError(t, os.WriteFile(file, []byte("extends: common.yaml\nhost: app.host.com\n"), 0644))
+
+		cfg, f, err := config.LoadFiles[*TestLayeredConfig](config.WithFile(file))
+		require.NoError(t, err)
+		assert.Equal(t, []string{base, common, file}, f)
+		assert.Equal(t, "app.host.com", cfg.Host)
+		assert.Equal(t, 2000, cfg.Port)
+		assert.Equal(t, "base", cfg.Server.Name)
+	})
+
+	t.Run("extends json", func(t *testing.T) {
+		file := filepath.Join(tempDir, "extends.json")
+		require.NoError(t, os.WriteFile(file, []byte(`{"$extends": ["shared/base.yaml"], "port": 3000}`), 0644))
+
+		cfg, f, err := config.LoadFiles[*TestLayeredConfig](config.WithFile(file))
+		require.NoError(t, err)
+		assert.Equal(t, []string{base, file}, f)
+		assert.Equal(t, "base.host.com", cfg.Host)
+		assert.Equal(t, 3000, cfg.Port)
+	})
+
+	t.Run("include", func(t *testing.T) {
+		file := filepath.Join(tempDir, "include.yaml")
+		require.NoError(t, os.WriteFile(file, []byte("host: app.host.com\nserver: !include shared/server.yaml\ntls: !include shared/tls.json\n"), 0644))
+
+		cfg, f, err := config.LoadFiles[*TestLayeredConfig](config.WithFile(file))
+		require.NoError(t, err)
+		assert.Equal(t, []string{server, tls, file}, f)
+		assert.Equal(t, "app.host.com", cfg.Host)
+		assert.Equal(t, "included", cfg.Server.Name)
+		assert.Equal(t, 5, cfg.Server.Timeout)
+		require.NotNil(t, cfg.TLS)
+		assert.Equal(t, "tls.pem", cfg.TLS.Cert)
+		assert.Equal(t, "key.pem", cfg.TLS.Key)
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		a := filepath.Join(tempDir, "a.yaml")
+		b := filepath.Join(tempDir, "b.yaml")
+		require.NoError(t, os.WriteFile(a, []byte("extends: b.yaml\n"), 0644))
+		require.NoError(t, os.WriteFile(b, []byte("server: !include a.yaml\n"), 0644))
+
+		_, _, err := config.Load[*TestLayeredConfig](config.WithFile(a))
+		assert.ErrorContains(t, err, "include cycle")
+	})
+
+	t.Run("path traversal", func(t *testing.T) {
+		file := filepath.Join(shared, "traversal.yaml")
+		require.NoError(t, os.WriteFile(file, []byte("extends: ../common.yaml\n"), 0644))
+
+		_, _, err := config.Load[*TestLayeredConfig](config.WithFile(file))
+		assert.ErrorContains(t, err, "path traversal attempt")
+
+		require.NoError(t, os.WriteFile(file, []byte("server: !include ../shared/server.yaml\n"), 0644))
+
+		_, _, err = config.Load[*TestLayeredConfig](config.WithFile(file))
+		assert.ErrorContains(t, err, "path traversal attempt")
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		file := filepath.Join(tempDir, "missing.yaml")
+		require.NoError(t, os.WriteFile(file, []byte("extends: unknown.yaml\n"), 0644))
+
+		_, name, err := config.Load[*TestLayeredConfig](config.WithFile(file))
+		assert.True(t, os.IsNotExist(err))
+		assert.Equal(t, filepath.Join(tempDir, "unknown.yaml"), name)
+	})
+}
+
+func TestLoad_Validation(t *testing.T) {
+	type ValidatedConfig struct {
+		Host  string `check:"required"`
+		Port  int    `default:"8080" check:"min=1,max=65535"`
+		Level string `default:"info" check:"oneof=debug info warn"`
+	}
+
+	require.NoError(t, os.Chdir(t.TempDir()))
+
+	file := filepath.Join(t.TempDir(), "validate.yaml")
+	require.NoError(t, os.WriteFile(file, []byte("port: 0\nlevel: trace\n"), 0644))
+
+	t.Run("all violations", func(t *testing.T) {
+		t.Setenv("VALIDATE_APP_PORT", "70000")
+
+		_, _, err := config.Load[*ValidatedConfig](config.WithFile(file), config.WithName("validate-app"))
+
+		var errs validate.Errors
+		require.ErrorAs(t, err, &errs)
+		assert.Len(t, errs, 3)
+		assert.ErrorContains(t, err, "host (VALIDATE_APP_HOST): is required")
+		assert.ErrorContains(t, err, "port (VALIDATE_APP_PORT): must be at most 65535, got 70000")
+		assert.ErrorContains(t, err, "level (VALIDATE_APP_LEVEL): must be one of [debug info warn], got 'trace'")
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		t.Setenv("VALIDATE_APP_HOST", "localhost")
+		t.Setenv("VALIDATE_APP_PORT", "9000")
+		t.Setenv("VALIDATE_APP_LEVEL", "warn")
+
+		cfg, _, err := config.Load[*ValidatedConfig](config.WithFile(file), config.WithName("validate-app"))
+		require.NoError(t, err)
+		assert.Equal(t, 9000, cfg.Port)
+	})
+
+	t.Run("skip", func(t *testing.T) {
+		cfg, _, err := config.Load[*ValidatedConfig](config.WithFile(file), config.SkipValidation)
+		require.NoError(t, err)
+		assert.Equal(t, "trace", cfg.Level)
+	})
+
+	t.Run("foreign rules", func(t *testing.T) {
+		type ForeignConfig struct {
+			Mail string `validate:"required,email"`
+			Port int    `validate:"gte=1" check:"min=1"`
+		}
+
+		require.NoError(t, os.WriteFile(file, []byte("port: 8080\n"), 0644))
+
+		cfg, _, err := config.Load[*ForeignConfig](config.WithFile(file))
+		require.NoError(t, err)
+		assert.Equal(t, 8080, cfg.Port)
+	})
+}
@@ -0,0 +1,242 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package config
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/zauberhaus/config/pkg/index"
+)
+
+// Redacted replaces the values of secrets in diffs, dumps and reports.
+const Redacted = index.Redacted
+
+var (
+	textUnmarshaler = reflect.TypeFor[encoding.TextUnmarshaler]()
+	secretType      = reflect.TypeFor[index.Secret]()
+)
+
+// Change is a field which differs between two configs. Old or New is nil if
+// the field doesn't exist in one of them.
+type Change struct {
+	Path string
+	Old  any
+	New  any
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("%s: %v -> %v", c.Path, c.Old, c.New)
+}
+
+// Diff returns the changed fields of two configs. Nested structs, slices
+// and maps are compared element by element, the values of secrets are
+// redacted.
+func Diff[T any](old *T, new *T) []Change {
+	var changes []Change
+
+	diff(reflect.ValueOf(old), reflect.ValueOf(new), "", false, &changes)
+
+	return changes
+}
+
+// DiffFiles loads the config for both files and returns the changed fields.
+func DiffFiles[T any](old string, new string, options ...Option) ([]Change, error) {
+	c1, _, _, err := load[T](append(options, WithFile(old))...)
+	if err != nil {
+		return nil, err
+	}
+
+	return DiffFile(c1, new, options...)
+}
+
+// DiffFile loads the config for the file and returns the fields which would
+// change compared to the current config.
+func DiffFile[T any](current *T, file string, options ...Option) ([]Change, error) {
+	cfg, _, _, err := load[T](append(options, WithFile(file))...)
+	if err != nil {
+		return nil, err
+	}
+
+	return Diff(current, cfg), nil
+}
+
+func diff(v1 reflect.Value, v2 reflect.Value, path string, secret bool, changes *[]Change) {
+	v1 = indirect(v1)
+	v2 = indirect(v2)
+
+	if !v1.IsValid() && !v2.IsValid() {
+		return
+	}
+
+	var t reflect.Type
+	if v1.IsValid() {
+		t = v1.Type()
+	} else {
+		t = v2.Type()
+	}
+
+	// values of different types in interface fields are compared as a whole
+	if v1.IsValid() && v2.IsValid() && v1.Type() != v2.Type() {
+		t = reflect.TypeFor[any]()
+	}
+
+	switch {
+	case isLeaf(t):
+		a := interfaceOf(v1)
+		b := interfaceOf(v2)
+
+		if reflect.DeepEqual(a, b) {
+			return
+		}
+
+		if secret || t.Implements(secretType) {
+			a = redact(a)
+			b = redact(b)
+		}
+
+		*changes = append(*changes, Change{
+			Path: path,
+			Old:  a,
+			New:  b,
+		})
+	case t.Kind() == reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() || field.Tag.Get("env") == "--" {
+				continue
+			}
+
+			diff(fieldByIndex(v1, i), fieldByIndex(v2, i), join(path, strings.ToLower(field.Name)), secret || index.IsSecret(field), changes)
+		}
+	case t.Kind() == reflect.Slice || t.Kind() == reflect.Array:
+		n := max(length(v1), length(v2))
+
+		for i := 0; i < n; i++ {
+			diff(elem(v1, i), elem(v2, i), fmt.Sprintf("%s[%d]", path, i), secret, changes)
+		}
+	case t.Kind() == reflect.Map:
+		keys := map[string]reflect.Value{}
+
+		for _, v := range []reflect.Value{v1, v2} {
+			if v.IsValid() {
+				for _, k := range v.MapKeys() {
+					keys[fmt.Sprint(k.Interface())] = k
+				}
+			}
+		}
+
+		names := make([]string, 0, len(keys))
+		for k := range keys {
+			names = append(names, k)
+		}
+
+		slices.Sort(names)
+
+		for _, name := range names {
+			k := keys[name]
+			diff(mapIndex(v1, k), mapIndex(v2, k), fmt.Sprintf("%s[%s]", path, name), secret, changes)
+		}
+	}
+}
+
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+
+		v = v.Elem()
+	}
+
+	return v
+}
+
+// isLeaf returns true for values which are compared as a whole, like
+// strings, time.Time or net.IP.
+func isLeaf(t reflect.Type) bool {
+	if reflect.PointerTo(t).Implements(textUnmarshaler) {
+		return true
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).IsExported() {
+				return false
+			}
+		}
+
+		return true
+	case reflect.Slice, reflect.Array:
+		return t.Elem().Kind() == reflect.Uint8
+	case reflect.Map:
+		return false
+	default:
+		return true
+	}
+}
+
+func fieldByIndex(v reflect.Value, i int) reflect.Value {
+	if !v.IsValid() {
+		return v
+	}
+
+	return v.Field(i)
+}
+
+func length(v reflect.Value) int {
+	if !v.IsValid() {
+		return 0
+	}
+
+	return v.Len()
+}
+
+func elem(v reflect.Value, i int) reflect.Value {
+	if !v.IsValid() || i >= v.Len() {
+		return reflect.Value{}
+	}
+
+	return v.Index(i)
+}
+
+func mapIndex(v reflect.Value, k reflect.Value) reflect.Value {
+	if !v.IsValid() {
+		return v
+	}
+
+	return v.MapIndex(k)
+}
+
+func interfaceOf(v reflect.Value) any {
+	if !v.IsValid() || !v.CanInterface() {
+		return nil
+	}
+
+	return v.Interface()
+}
+
+// redact masks a value, empty values are kept to show that a secret was
+// set or removed.
+func redact(v any) any {
+	if v == nil || reflect.ValueOf(v).IsZero() {
+		return v
+	}
+
+	return Redacted
+}
+
+func join(path string, name string) string {
+	if path == "" {
+		return name
+	}
+
+	return path + "." + name
+}
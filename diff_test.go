@@ -0,0 +1,145 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package config_test
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zauberhaus/config"
+)
+
+type DiffServer struct {
+	Host string
+	Port int
+}
+
+type DiffConfig struct {
+	Name     string
+	Password string `secret:"true"`
+	Timeout  time.Duration
+	Started  time.Time
+	IP       net.IP
+	Server   DiffServer
+	Backups  []DiffServer
+	Tags     map[string]string
+	TLS      *struct {
+		Cert string
+		Key  string `secret:"true"`
+	}
+	Extra    any
+	Internal string `env:"--"`
+}
+
+func TestDiff(t *testing.T) {
+	now := time.Now()
+
+	old := &DiffConfig{
+		Name:     "app",
+		Password: "secret1",
+		Timeout:  time.Second,
+		Started:  now,
+		IP:       net.ParseIP("10.0.0.1"),
+		Server:   DiffServer{Host: "localhost", Port: 80},
+		Backups:  []DiffServer{{Host: "b1", Port: 1}, {Host: "b2", Port: 2}},
+		Tags:     map[string]string{"a": "1", "b": "2"},
+		Extra:    map[string]any{"x": 1},
+		Internal: "a",
+	}
+
+	t.Run("equal", func(t *testing.T) {
+		new := *old
+		assert.Empty(t, config.Diff(old, &new))
+	})
+
+	t.Run("changes", func(t *testing.T) {
+		new := *old
+		new.Password = "secret2"
+		new.Timeout = 2 * time.Second
+		new.Started = now.Add(time.Hour)
+		new.IP = net.ParseIP("10.0.0.2")
+		new.Server.Port = 8080
+		new.Backups = []DiffServer{{Host: "b1", Port: 10}}
+		new.Tags = map[string]string{"a": "1", "c": "3"}
+		new.TLS = &struct {
+			Cert string
+			Key  string `secret:"true"`
+		}{Cert: "cert.pem", Key: "key"}
+		new.Extra = "text"
+		new.Internal = "b"
+
+		changes := config.Diff(old, &new)
+		assert.Equal(t, []config.Change{
+			{Path: "password", Old: config.Redacted, New: config.Redacted},
+			{Path: "timeout", Old: time.Second, New: 2 * time.Second},
+			{Path: "started", Old: now, New: now.Add(time.Hour)},
+			{Path: "ip", Old: net.ParseIP("10.0.0.1"), New: net.ParseIP("10.0.0.2")},
+			{Path: "server.port", Old: 80, New: 8080},
+			{Path: "backups[0].port", Old: 1, New: 10},
+			{Path: "backups[1].host", Old: "b2", New: nil},
+			{Path: "backups[1].port", Old: 2, New: nil},
+			{Path: "tags[b]", Old: "2", New: nil},
+			{Path: "tags[c]", Old: nil, New: "3"},
+			{Path: "tls.cert", Old: nil, New: "cert.pem"},
+			{Path: "tls.key", Old: nil, New: config.Redacted},
+			{Path: "extra", Old: map[string]any{"x": 1}, New: "text"},
+		}, changes)
+
+		assert.Equal(t, "server.port: 80 -> 8080", changes[4].String())
+	})
+
+	t.Run("secret type", func(t *testing.T) {
+		old := &SecretTestConfig{Password: config.NewSecret("a")}
+		new := &SecretTestConfig{Password: config.NewSecret("b")}
+
+		assert.Equal(t, []config.Change{
+			{Path: "password", Old: config.Redacted, New: config.Redacted},
+		}, config.Diff(old, new))
+	})
+}
+
+func TestDiffFiles(t *testing.T) {
+	require.NoError(t, os.Chdir(t.TempDir()))
+
+	dir := t.TempDir()
+
+	current := filepath.Join(dir, "current.yaml")
+	require.NoError(t, os.WriteFile(current, []byte("name: app\nserver:\n  port: 80\n"), 0644))
+
+	next := filepath.Join(dir, "next.yaml")
+	require.NoError(t, os.WriteFile(next, []byte("name: app\nserver:\n  port: 8080\npassword: new\n"), 0644))
+
+	t.Run("files", func(t *testing.T) {
+		changes, err := config.DiffFiles[DiffConfig](current, next)
+		require.NoError(t, err)
+		assert.Equal(t, []config.Change{
+			{Path: "password", Old: "", New: config.Redacted},
+			{Path: "server.port", Old: 80, New: 8080},
+		}, changes)
+	})
+
+	t.Run("live", func(t *testing.T) {
+		live := &DiffConfig{Name: "live", Password: "new"}
+		live.Server.Port = 8080
+
+		changes, err := config.DiffFile(live, next)
+		require.NoError(t, err)
+		assert.Equal(t, []config.Change{{Path: "name", Old: "live", New: "app"}}, changes)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		_, err := config.DiffFiles[DiffConfig](filepath.Join(dir, "missing.yaml"), next)
+		assert.True(t, os.IsNotExist(err))
+
+		_, err = config.DiffFiles[DiffConfig](current, filepath.Join(dir, "missing.yaml"))
+		assert.True(t, os.IsNotExist(err))
+	})
+}
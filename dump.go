@@ -0,0 +1,84 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/zauberhaus/config/pkg/index"
+)
+
+// Dump encodes the config with the encoder of the file type. The values of
+// secrets are redacted and nil values are omitted.
+func Dump(cfg any, ft FileType) ([]byte, error) {
+	return ft.Encode(dump(reflect.ValueOf(cfg), false))
+}
+
+func dump(v reflect.Value, secret bool) any {
+	v = indirect(v)
+	if !v.IsValid() {
+		return nil
+	}
+
+	t := v.Type()
+
+	switch {
+	case t.Implements(secretType):
+		return Redacted
+	case isLeaf(t):
+		val := interfaceOf(v)
+		if secret {
+			return redact(val)
+		}
+
+		return val
+	case t.Kind() == reflect.Struct:
+		m := map[string]any{}
+
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() || field.Tag.Get("env") == "--" {
+				continue
+			}
+
+			if val := dump(v.Field(i), secret || index.IsSecret(field)); val != nil {
+				m[strings.ToLower(field.Name)] = val
+			}
+		}
+
+		return m
+	case t.Kind() == reflect.Slice || t.Kind() == reflect.Array:
+		if t.Kind() == reflect.Slice && v.IsNil() {
+			return nil
+		}
+
+		list := make([]any, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			list = append(list, dump(v.Index(i), secret))
+		}
+
+		return list
+	case t.Kind() == reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+
+		m := map[string]any{}
+
+		iter := v.MapRange()
+		for iter.Next() {
+			if val := dump(iter.Value(), secret); val != nil {
+				m[fmt.Sprint(iter.Key().Interface())] = val
+			}
+		}
+
+		return m
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zauberhaus/config"
+)
+
+func TestDump(t *testing.T) {
+	cfg := &DiffConfig{
+		Name:     "app",
+		Password: "s3cr3t",
+		Timeout:  time.Second,
+		Server:   DiffServer{Host: "localhost", Port: 80},
+		Tags:     map[string]string{"a": "1"},
+		TLS: &struct {
+			Cert string
+			Key  string `secret:"true"`
+		}{Cert: "cert.pem", Key: "key"},
+		Internal: "hidden",
+	}
+
+	data, err := config.Dump(cfg, config.YAML)
+	require.NoError(t, err)
+
+	txt := string(data)
+	assert.Contains(t, txt, "name: app\n")
+	assert.Contains(t, txt, "password: '******'\n")
+	assert.Contains(t, txt, "server:\n    host: localhost\n    port: 80\n")
+	assert.Contains(t, txt, "tags:\n    a: \"1\"\n")
+	assert.Contains(t, txt, "tls:\n    cert: cert.pem\n    key: '******'\n")
+	assert.NotContains(t, txt, "s3cr3t")
+	assert.NotContains(t, txt, "internal")
+	assert.NotContains(t, txt, "extra")
+
+	t.Run("secret type", func(t *testing.T) {
+		data, err := config.Dump(&SecretTestConfig{Password: config.NewSecret("s3cr3t"), User: "admin"}, config.JSON)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"password": "******", "pin": "******", "key": "", "user": "admin"}`, string(data))
+	})
+
+	t.Run("toml", func(t *testing.T) {
+		data, err := config.Dump(cfg, config.TOML)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `password = "******"`)
+	})
+}
@@ -0,0 +1,416 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package config
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/zauberhaus/config/pkg/env"
+	"github.com/zauberhaus/config/pkg/index"
+	"go.yaml.in/yaml/v3"
+)
+
+const (
+	// EncryptionKeyEnv is the env var with the base64 encoded key to decrypt
+	// values, prefixed with the name like MY_APP_ENCRYPTION_KEY.
+	EncryptionKeyEnv = "ENCRYPTION_KEY"
+	// EncryptionKeyFileEnv is the env var with the path of a key file.
+	EncryptionKeyFileEnv = EncryptionKeyEnv + env.FileSuffix
+
+	encryptedPrefix = "ENC["
+	encryptedSuffix = "]"
+	algorithm       = "AES256_GCM"
+)
+
+// NewEncryptionKey returns a random base64 encoded key.
+func NewEncryptionKey() (string, error) {
+	key := make([]byte, 32)
+
+	_, err := rand.Read(key)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(key), nil
+}
+
+// IsEncrypted returns true if the value has the form ENC[...].
+func IsEncrypted(val string) bool {
+	return strings.HasPrefix(val, encryptedPrefix) && strings.HasSuffix(val, encryptedSuffix)
+}
+
+// Encrypt encrypts a value with AES-256-GCM and returns it in the form
+// ENC[AES256_GCM,<base64>]. The key must be base64 encoded.
+func Encrypt(key string, val string) (string, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return "", err
+	}
+
+	data := aead.Seal(nonce, nonce, []byte(val), nil)
+
+	return encryptedPrefix + algorithm + "," + base64.StdEncoding.EncodeToString(data) + encryptedSuffix, nil
+}
+
+// Decrypt returns the plain text of a value encrypted with Encrypt.
+func Decrypt(key string, val string) (string, error) {
+	if !IsEncrypted(val) {
+		return "", fmt.Errorf("invalid encrypted value")
+	}
+
+	alg, payload, ok := strings.Cut(strings.TrimSuffix(strings.TrimPrefix(val, encryptedPrefix), encryptedSuffix), ",")
+	if !ok {
+		return "", fmt.Errorf("invalid encrypted value")
+	}
+
+	if alg != algorithm {
+		return "", fmt.Errorf("unsupported encryption: %s", alg)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		return "", fmt.Errorf("invalid encrypted value: %w", err)
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return "", err
+	}
+
+	if len(data) < aead.NonceSize() {
+		return "", fmt.Errorf("invalid encrypted value")
+	}
+
+	plain, err := aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], nil)
+	if err != nil {
+		return "", fmt.Errorf("decryption failed: %w", err)
+	}
+
+	return string(plain), nil
+}
+
+// EncryptFile encrypts the values of all secrets of the config type in a
+// YAML or JSON file and writes the file back. Encrypted values are kept,
+// other file types are rejected.
+func EncryptFile[T any](name string, key string, options ...Option) error {
+	o := &ConfigOptions{}
+	for _, opt := range options {
+		opt.Set(o)
+	}
+
+	// other formats would be rewritten as YAML
+	ft := fileType(o, name)
+	if ft != YAML && ft != JSON {
+		return fmt.Errorf("can't encrypt %s: unsupported file type %v", name, ft)
+	}
+
+	fi, err := os.Stat(name)
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return err
+	}
+
+	idx, err := index.New[T](o.Replacer)
+	if err != nil {
+		return err
+	}
+
+	secrets := map[string]bool{}
+
+	for _, item := range idx {
+		if item.Secret {
+			secrets[normalize(item.Path)] = true
+		}
+	}
+
+	var node yaml.Node
+
+	err = yaml.Unmarshal(data, &node)
+	if err != nil {
+		return err
+	}
+
+	err = encryptNode(&node, "", false, secrets, key)
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+
+	data, err = encodeNode(&node, ft)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(name, data, fi.Mode())
+}
+
+func newAEAD(key string) (cipher.AEAD, error) {
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(key))
+	if err != nil {
+		return nil, fmt.Errorf("invalid encryption key: %w", err)
+	}
+
+	if len(data) != 32 {
+		return nil, fmt.Errorf("invalid encryption key: must be 32 bytes, got %d", len(data))
+	}
+
+	block, err := aes.NewCipher(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// encryptionKey returns the key set by option, key file or env var.
+func encryptionKey(o *ConfigOptions) (string, error) {
+	switch {
+	case o.EncryptionKey != "":
+		return o.EncryptionKey, nil
+	case o.EncryptionKeyFile != "":
+		data, err := os.ReadFile(o.EncryptionKeyFile)
+		return string(data), err
+	}
+
+	prefix := env.Prefix(o.Name)
+
+	if key, ok := os.LookupEnv(prefix + EncryptionKeyEnv); ok {
+		return key, nil
+	}
+
+	if name, ok := os.LookupEnv(prefix + EncryptionKeyFileEnv); ok {
+		data, err := os.ReadFile(strings.TrimSpace(name))
+		return string(data), err
+	}
+
+	return "", fmt.Errorf("no encryption key")
+}
+
+// decryptNode replaces all encrypted values by their plain text. The path
+// of the node is used to keep the plain text of string fields a string.
+func decryptNode(o *ConfigOptions, node *yaml.Node, path string, key *string) error {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		if !IsEncrypted(node.Value) {
+			return nil
+		}
+
+		if *key == "" {
+			tmp, err := encryptionKey(o)
+			if err != nil {
+				return err
+			}
+
+			*key = tmp
+		}
+
+		val, err := Decrypt(*key, node.Value)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", node.Line, err)
+		}
+
+		node.Value = val
+		node.Style = 0
+
+		// plain texts like 1234 or null stay strings for string fields, the
+		// type of other values is resolved from the plain text
+		if isText(fieldType(o, path)) {
+			node.Tag = "!!str"
+		} else {
+			node.Tag = ""
+		}
+	case yaml.MappingNode:
+		isMap := false
+		if t := fieldType(o, path); t != nil && t.Kind() == reflect.Map {
+			isMap = true
+		}
+
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			p := path + "[]"
+			if !isMap {
+				p = join(path, normalize(node.Content[i].Value))
+			}
+
+			err := decryptNode(o, node.Content[i+1], p, key)
+			if err != nil {
+				return err
+			}
+		}
+	case yaml.SequenceNode:
+		for _, c := range node.Content {
+			err := decryptNode(o, c, path+"[]", key)
+			if err != nil {
+				return err
+			}
+		}
+	default:
+		for _, c := range node.Content {
+			err := decryptNode(o, c, path, key)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// decryptData decrypts the values of a file in a format like TOML, which
+// is decoded and encoded again with the encoder of the format.
+func decryptData(o *ConfigOptions, ft FileType, data []byte) ([]byte, error) {
+	var val map[string]any
+
+	err := ft.Decode(data, &val)
+	if err != nil {
+		return nil, err
+	}
+
+	var node yaml.Node
+
+	err = node.Encode(val)
+	if err != nil {
+		return nil, err
+	}
+
+	var key string
+
+	err = decryptNode(o, &node, "", &key)
+	if err != nil {
+		return nil, err
+	}
+
+	val = nil
+
+	err = node.Decode(&val)
+	if err != nil {
+		return nil, err
+	}
+
+	return ft.Encode(val)
+}
+
+// fieldType returns the type of the field with the normalized path or nil.
+func fieldType(o *ConfigOptions, path string) reflect.Type {
+	if path == "" {
+		return nil
+	}
+
+	for _, item := range o.Index {
+		if normalize(item.Path) == path {
+			return item.Type
+		}
+	}
+
+	return nil
+}
+
+// isText returns true for string types, pointers to them and secrets with
+// a string value.
+func isText(t reflect.Type) bool {
+	if t == nil {
+		return false
+	}
+
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if s, ok := reflect.New(t).Interface().(secretValue); ok {
+		t = s.ref().Type()
+	}
+
+	return t.Kind() == reflect.String
+}
+
+// encryptNode encrypts the scalars of secret fields.
+func encryptNode(node *yaml.Node, path string, secret bool, secrets map[string]bool, key string) error {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, c := range node.Content {
+			err := encryptNode(c, path, secret, secrets, key)
+			if err != nil {
+				return err
+			}
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			p := join(path, normalize(node.Content[i].Value))
+
+			err := encryptNode(node.Content[i+1], p, secret || secrets[p], secrets, key)
+			if err != nil {
+				return err
+			}
+		}
+	case yaml.SequenceNode:
+		for _, c := range node.Content {
+			err := encryptNode(c, path+"[]", secret || secrets[path+"[]"], secrets, key)
+			if err != nil {
+				return err
+			}
+		}
+	case yaml.ScalarNode:
+		if !secret || node.Tag == "!!null" || IsEncrypted(node.Value) {
+			return nil
+		}
+
+		val, err := Encrypt(key, node.Value)
+		if err != nil {
+			return err
+		}
+
+		node.Value = val
+		node.Tag = "!!str"
+		node.Style = 0
+	}
+
+	return nil
+}
+
+// encodeNode encodes a node in the format of the file.
+func encodeNode(node *yaml.Node, ft FileType) ([]byte, error) {
+	if ft != JSON {
+		var buf bytes.Buffer
+
+		// the usual indentation keeps the diff of an encrypted file small
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+
+		err := enc.Encode(node)
+		if err == nil {
+			err = enc.Close()
+		}
+
+		return buf.Bytes(), err
+	}
+
+	var val any
+
+	err := node.Decode(&val)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.MarshalIndent(val, "", "  ")
+}
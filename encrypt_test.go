@@ -0,0 +1,300 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package config_test
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zauberhaus/config"
+	"go.yaml.in/yaml/v3"
+)
+
+type EncryptTestConfig struct {
+	User     string
+	Password string `secret:"true"`
+	Token    config.Secret[string]
+	Port     int
+	Database struct {
+		Host string
+		Keys []string
+	} `secret:"true"`
+}
+
+func TestEncrypt(t *testing.T) {
+	key, err := config.NewEncryptionKey()
+	require.NoError(t, err)
+
+	val, err := config.Encrypt(key, "s3cr3t")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(val, "ENC[AES256_GCM,"))
+	assert.True(t, config.IsEncrypted(val))
+	assert.NotContains(t, val, "s3cr3t")
+
+	other, err := config.Encrypt(key, "s3cr3t")
+	require.NoError(t, err)
+	assert.NotEqual(t, val, other)
+
+	plain, err := config.Decrypt(key, val)
+	require.NoError(t, err)
+	assert.Equal(t, "s3cr3t", plain)
+
+	t.Run("wrong key", func(t *testing.T) {
+		key2, err := config.NewEncryptionKey()
+		require.NoError(t, err)
+
+		_, err = config.Decrypt(key2, val)
+		assert.ErrorContains(t, err, "decryption failed")
+	})
+
+	t.Run("invalid key", func(t *testing.T) {
+		_, err := config.Encrypt(base64.StdEncoding.EncodeToString([]byte("short")), "x")
+		assert.EqualError(t, err, "invalid encryption key: must be 32 bytes, got 5")
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		_, err := config.Decrypt(key, "ENC[RSA,abc]")
+		assert.EqualError(t, err, "unsupported encryption: RSA")
+
+		_, err = config.Decrypt(key, "plain")
+		assert.EqualError(t, err, "invalid encrypted value")
+	})
+}
+
+func TestLoad_Encrypted(t *testing.T) {
+	tempDir := t.TempDir()
+	require.NoError(t, os.Chdir(tempDir))
+
+	key, err := config.NewEncryptionKey()
+	require.NoError(t, err)
+
+	password, err := config.Encrypt(key, "s3cr3t")
+	require.NoError(t, err)
+
+	port, err := config.Encrypt(key, "5432")
+	require.NoError(t, err)
+
+	file := filepath.Join(tempDir, "config.yaml")
+	require.NoError(t, os.WriteFile(file, []byte("user: admin\npassword: "+password+"\nport: "+port+"\n"), 0644))
+
+	jsonFile := filepath.Join(tempDir, "config.json")
+	require.NoError(t, os.WriteFile(jsonFile, []byte(`{"password": "`+password+`", "port": "`+port+`"}`), 0644))
+
+	tomlFile := filepath.Join(tempDir, "config.toml")
+	require.NoError(t, os.WriteFile(tomlFile, []byte("password = \""+password+"\"\nport = \""+port+"\"\n"), 0644))
+
+	keyFile := filepath.Join(tempDir, "key")
+	require.NoError(t, os.WriteFile(keyFile, []byte(key+"\n"), 0600))
+
+	tests := map[string]struct {
+		file    string
+		options []config.Option
+		env     map[string]string
+	}{
+		"option": {
+			file:    file,
+			options: []config.Option{config.WithEncryptionKey(key)},
+		},
+		"key file": {
+			file:    file,
+			options: []config.Option{config.WithEncryptionKeyFile(keyFile)},
+		},
+		"env": {
+			file: file,
+			env:  map[string]string{"ENC_ENCRYPTION_KEY": key},
+		},
+		"env key file": {
+			file: file,
+			env:  map[string]string{"ENC_ENCRYPTION_KEY_FILE": keyFile},
+		},
+		"json": {
+			file:    jsonFile,
+			options: []config.Option{config.WithEncryptionKey(key)},
+		},
+		"toml": {
+			file:    tomlFile,
+			options: []config.Option{config.WithEncryptionKey(key)},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			cfg, _, err := config.Load[*EncryptTestConfig](append(tt.options, config.WithFile(tt.file), config.WithName("enc"), config.Strict)...)
+			require.NoError(t, err)
+			assert.Equal(t, "s3cr3t", cfg.Password)
+			assert.Equal(t, 5432, cfg.Port)
+		})
+	}
+
+	t.Run("no key", func(t *testing.T) {
+		_, _, err := config.Load[*EncryptTestConfig](config.WithFile(file), config.WithName("enc"))
+		assert.EqualError(t, err, "decrypt "+file+": no encryption key")
+	})
+
+	t.Run("wrong key", func(t *testing.T) {
+		key2, err := config.NewEncryptionKey()
+		require.NoError(t, err)
+
+		_, _, err = config.Load[*EncryptTestConfig](config.WithFile(file), config.WithEncryptionKey(key2))
+		assert.ErrorContains(t, err, "decrypt "+file+": line 2: decryption failed")
+	})
+
+	t.Run("string values", func(t *testing.T) {
+		for _, plain := range []string{"1234", "true", "null", "~"} {
+			password, err := config.Encrypt(key, plain)
+			require.NoError(t, err)
+
+			token, err := config.Encrypt(key, plain)
+			require.NoError(t, err)
+
+			keys, err := config.Encrypt(key, plain)
+			require.NoError(t, err)
+
+			files := map[string]string{
+				"string.yaml": "password: " + password + "\ntoken: " + token + "\ndatabase:\n  keys:\n    - " + keys + "\n",
+				"string.json": `{"password": "` + password + `", "token": "` + token + `", "database": {"keys": ["` + keys + `"]}}`,
+				"string.toml": "password = \"" + password + "\"\ntoken = \"" + token + "\"\n[database]\nkeys = [\"" + keys + "\"]\n",
+			}
+
+			for name, content := range files {
+				file := filepath.Join(tempDir, name)
+				require.NoError(t, os.WriteFile(file, []byte(content), 0644))
+
+				cfg, _, err := config.Load[*EncryptTestConfig](config.WithFile(file), config.WithEncryptionKey(key))
+				require.NoError(t, err, name+": "+plain)
+				assert.Equal(t, plain, cfg.Password, name)
+				assert.Equal(t, plain, cfg.Token.Value(), name)
+				assert.Equal(t, []string{plain}, cfg.Database.Keys, name)
+			}
+		}
+	})
+
+	t.Run("no encoder", func(t *testing.T) {
+		ft := config.RegisterFormat("enc-decode-only", []string{".dec"}, toml.Unmarshal)
+
+		file := filepath.Join(tempDir, "config.dec")
+		require.NoError(t, os.WriteFile(file, []byte("password = \""+password+"\"\n"), 0644))
+
+		_, _, err := config.Load[*EncryptTestConfig](config.WithFile(file), config.WithEncryptionKey(key), config.WithExtension(".dec", ft))
+		assert.EqualError(t, err, "decrypt "+file+": no encoder for file type: enc-decode-only")
+	})
+
+	t.Run("include", func(t *testing.T) {
+		main := filepath.Join(tempDir, "main.yaml")
+		require.NoError(t, os.WriteFile(main, []byte("user: main\ndatabase: !include db.yaml\n"), 0644))
+
+		host, err := config.Encrypt(key, "db.local")
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(tempDir, "db.yaml"), []byte("host: "+host+"\n"), 0644))
+
+		cfg, _, err := config.Load[*EncryptTestConfig](config.WithFile(main), config.WithEncryptionKey(key))
+		require.NoError(t, err)
+		assert.Equal(t, "db.local", cfg.Database.Host)
+	})
+}
+
+func TestEncryptFile(t *testing.T) {
+	tempDir := t.TempDir()
+	require.NoError(t, os.Chdir(tempDir))
+
+	key, err := config.NewEncryptionKey()
+	require.NoError(t, err)
+
+	encrypted, err := config.Encrypt(key, "old")
+	require.NoError(t, err)
+
+	file := filepath.Join(tempDir, "config.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(`# credentials
+user: admin
+password: s3cr3t
+token: t0k3n
+port: 8080
+database:
+  host: db.local
+  keys:
+    - k1
+    - `+encrypted+`
+`), 0600))
+
+	require.NoError(t, config.EncryptFile[EncryptTestConfig](file, key))
+
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+
+	txt := string(data)
+	assert.Contains(t, txt, "# credentials\nuser: admin\n")
+	assert.Contains(t, txt, "port: 8080\n")
+	assert.Contains(t, txt, encrypted)
+
+	// only the lines of the secrets change
+	lines := strings.Split(txt, "\n")
+	require.Len(t, lines, 11)
+	assert.Equal(t, "database:", lines[5])
+	assert.True(t, strings.HasPrefix(lines[6], "  host: ENC["))
+	assert.Equal(t, "  keys:", lines[7])
+	assert.True(t, strings.HasPrefix(lines[8], "    - ENC["))
+
+	for _, v := range []string{"s3cr3t", "t0k3n", "db.local", "k1"} {
+		assert.NotContains(t, txt, v)
+	}
+
+	var m map[string]any
+	require.NoError(t, yaml.Unmarshal(data, &m))
+	assert.True(t, config.IsEncrypted(m["password"].(string)))
+
+	fi, err := os.Stat(file)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), fi.Mode().Perm())
+
+	cfg, _, err := config.Load[*EncryptTestConfig](config.WithFile(file), config.WithEncryptionKey(key))
+	require.NoError(t, err)
+	assert.Equal(t, "admin", cfg.User)
+	assert.Equal(t, "s3cr3t", cfg.Password)
+	assert.Equal(t, "t0k3n", cfg.Token.Value())
+	assert.Equal(t, 8080, cfg.Port)
+	assert.Equal(t, "db.local", cfg.Database.Host)
+	assert.Equal(t, []string{"k1", "old"}, cfg.Database.Keys)
+
+	t.Run("unsupported", func(t *testing.T) {
+		content := "name = \"x\"\npassword = \"s3cr3t\"\n"
+
+		file := filepath.Join(tempDir, "config.toml")
+		require.NoError(t, os.WriteFile(file, []byte(content), 0600))
+
+		err := config.EncryptFile[EncryptTestConfig](file, key)
+		assert.EqualError(t, err, "can't encrypt "+file+": unsupported file type toml")
+
+		data, err := os.ReadFile(file)
+		require.NoError(t, err)
+		assert.Equal(t, content, string(data))
+	})
+
+	t.Run("json", func(t *testing.T) {
+		file := filepath.Join(tempDir, "config.json")
+		require.NoError(t, os.WriteFile(file, []byte(`{"user": "admin", "password": "s3cr3t", "port": 8080}`), 0644))
+
+		require.NoError(t, config.EncryptFile[EncryptTestConfig](file, key))
+
+		data, err := os.ReadFile(file)
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "s3cr3t")
+
+		cfg, _, err := config.Load[*EncryptTestConfig](config.WithFile(file), config.WithEncryptionKey(key))
+		require.NoError(t, err)
+		assert.Equal(t, "s3cr3t", cfg.Password)
+		assert.Equal(t, 8080, cfg.Port)
+	})
+}
@@ -0,0 +1,164 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/BurntSushi/toml"
+	"go.yaml.in/yaml/v3"
+)
+
+type Decoder func(data []byte, v any) error
+type Encoder func(v any) ([]byte, error)
+
+type Format struct {
+	Name       string
+	FileType   FileType
+	Extensions []string
+	Decoder    Decoder
+	Encoder    Encoder
+}
+
+var (
+	formatsLock sync.RWMutex
+	formats     = []Format{
+		{
+			Name:       "json",
+			FileType:   JSON,
+			Extensions: []string{".json"},
+			Decoder:    json.Unmarshal,
+			Encoder: func(v any) ([]byte, error) {
+				return json.MarshalIndent(v, "", "  ")
+			},
+		},
+		{
+			Name:       "yaml",
+			FileType:   YAML,
+			Extensions: []string{".yaml", ".yml"},
+			Decoder:    yaml.Unmarshal,
+			Encoder:    yaml.Marshal,
+		},
+		{
+			Name:       "toml",
+			FileType:   TOML,
+			Extensions: []string{".toml"},
+			Decoder:    toml.Unmarshal,
+			Encoder:    toml.Marshal,
+		},
+	}
+)
+
+// RegisterFormat adds a file format or replaces the extensions and decoder
+// of an already registered format with the same name.
+func RegisterFormat(name string, exts []string, decoder Decoder) FileType {
+	formatsLock.Lock()
+	defer formatsLock.Unlock()
+
+	name = strings.ToLower(name)
+
+	for i, f := range formats {
+		if f.Name == name {
+			formats[i].Extensions = slices.Clone(exts)
+			formats[i].Decoder = decoder
+			return f.FileType
+		}
+	}
+
+	ft := FileType(len(formats) + 1)
+
+	formats = append(formats, Format{
+		Name:       name,
+		FileType:   ft,
+		Extensions: slices.Clone(exts),
+		Decoder:    decoder,
+	})
+
+	return ft
+}
+
+func RegisterEncoder(ft FileType, encoder Encoder) error {
+	formatsLock.Lock()
+	defer formatsLock.Unlock()
+
+	for i, f := range formats {
+		if f.FileType == ft {
+			formats[i].Encoder = encoder
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown file type: %d", ft)
+}
+
+func Formats() []Format {
+	formatsLock.RLock()
+	defer formatsLock.RUnlock()
+
+	return slices.Clone(formats)
+}
+
+func GetFormat(ft FileType) (Format, bool) {
+	formatsLock.RLock()
+	defer formatsLock.RUnlock()
+
+	for _, f := range formats {
+		if f.FileType == ft {
+			return f, true
+		}
+	}
+
+	return Format{}, false
+}
+
+// Extensions returns the file extensions of all registered formats.
+func Extensions() []Extension {
+	formatsLock.RLock()
+	defer formatsLock.RUnlock()
+
+	var result []Extension
+
+	for _, f := range formats {
+		for _, e := range f.Extensions {
+			result = append(result, Extension{
+				Name:     e,
+				FileType: f.FileType,
+			})
+		}
+	}
+
+	return result
+}
+
+func (ft FileType) String() string {
+	if f, ok := GetFormat(ft); ok {
+		return f.Name
+	}
+
+	return "unknown"
+}
+
+func (ft FileType) Decode(data []byte, v any) error {
+	f, ok := GetFormat(ft)
+	if !ok || f.Decoder == nil {
+		return fmt.Errorf("no decoder for file type: %v", ft)
+	}
+
+	return f.Decoder(data, v)
+}
+
+func (ft FileType) Encode(v any) ([]byte, error) {
+	f, ok := GetFormat(ft)
+	if !ok || f.Encoder == nil {
+		return nil, fmt.Errorf("no encoder for file type: %v", ft)
+	}
+
+	return f.Encoder(v)
+}
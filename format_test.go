@@ -0,0 +1,138 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package config_test
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zauberhaus/config"
+	"github.com/zauberhaus/lookup"
+)
+
+var formatRuns atomic.Int32
+
+// decodeProperties is a minimal key=value decoder used to test the registry
+func decodeProperties(data []byte, v any) error {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return fmt.Errorf("invalid line: %s", line)
+		}
+
+		if _, err := lookup.Set(v, strings.TrimSpace(key), strings.TrimSpace(value)); err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
+
+func TestRegisterFormat(t *testing.T) {
+	ft := config.RegisterFormat("properties", []string{".properties"}, decodeProperties)
+	assert.NotEqual(t, config.UnknownFileType, ft)
+	assert.Equal(t, "properties", ft.String())
+
+	t.Run("register again", func(t *testing.T) {
+		ft2 := config.RegisterFormat("Properties", []string{".properties", ".props"}, decodeProperties)
+		assert.Equal(t, ft, ft2)
+
+		f, ok := config.GetFormat(ft)
+		require.True(t, ok)
+		assert.Equal(t, []string{".properties", ".props"}, f.Extensions)
+		assert.Contains(t, config.Extensions(), config.Extension{Name: ".props", FileType: ft})
+	})
+
+	t.Run("file type", func(t *testing.T) {
+		assert.Equal(t, ft, config.GetFileType("app.props"))
+		assert.Equal(t, config.JSON, config.GetFileType("app.json"))
+	})
+
+	t.Run("load", func(t *testing.T) {
+		dir := t.TempDir()
+		file := filepath.Join(dir, "config.properties")
+		err := os.WriteFile(file, []byte("# test\nhost = props.host.com\nsub.name = props-sub\n"), 0644)
+		require.NoError(t, err)
+
+		require.NoError(t, os.Chdir(dir))
+
+		cfg, f, err := config.Load[*TestLoadConfig](config.WithPaths(dir))
+		require.NoError(t, err)
+		assert.Equal(t, file, f)
+		assert.Equal(t, "props.host.com", cfg.Host)
+		assert.Equal(t, 8080, cfg.Port)
+		assert.Equal(t, "props-sub", cfg.Sub.Name)
+	})
+
+	t.Run("custom extension", func(t *testing.T) {
+		dir := t.TempDir()
+		file := filepath.Join(dir, "app.cfg")
+		err := os.WriteFile(file, []byte("port = 7070\n"), 0644)
+		require.NoError(t, err)
+
+		cfg, f, err := config.Load[*TestLoadConfig](config.WithFile(file), config.WithExtension(".cfg", ft))
+		require.NoError(t, err)
+		assert.Equal(t, file, f)
+		assert.Equal(t, 7070, cfg.Port)
+	})
+
+	// the registry is global, a new format per run has no encoder yet
+	t.Run("no encoder", func(t *testing.T) {
+		ft := config.RegisterFormat(fmt.Sprintf("%s-%d", t.Name(), formatRuns.Add(1)), nil, decodeProperties)
+
+		_, err := ft.Encode(&TestLoadConfig{})
+		assert.ErrorContains(t, err, "no encoder")
+
+		err = config.RegisterEncoder(ft, func(v any) ([]byte, error) {
+			return []byte("encoded"), nil
+		})
+		require.NoError(t, err)
+
+		data, err := ft.Encode(&TestLoadConfig{})
+		require.NoError(t, err)
+		assert.Equal(t, "encoded", string(data))
+
+		err = config.RegisterEncoder(config.FileType(999), nil)
+		assert.ErrorContains(t, err, "unknown file type")
+	})
+}
+
+func TestFileType_Codec(t *testing.T) {
+	cfg := &TestLoadConfig{Host: "myhost", Port: 1234}
+
+	for _, ft := range []config.FileType{config.JSON, config.YAML, config.TOML} {
+		t.Run(ft.String(), func(t *testing.T) {
+			data, err := ft.Encode(cfg)
+			require.NoError(t, err)
+
+			var result TestLoadConfig
+			require.NoError(t, ft.Decode(data, &result))
+			assert.Equal(t, cfg.Host, result.Host)
+			assert.Equal(t, cfg.Port, result.Port)
+		})
+	}
+
+	t.Run("unknown", func(t *testing.T) {
+		assert.Equal(t, "unknown", config.UnknownFileType.String())
+		assert.Error(t, config.UnknownFileType.Decode([]byte("{}"), cfg))
+		_, err := config.UnknownFileType.Encode(cfg)
+		assert.Error(t, err)
+	})
+}
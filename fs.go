@@ -0,0 +1,119 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package config
+
+import (
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sync"
+)
+
+// Stdin is the file name for a config read from the standard input.
+const Stdin = "-"
+
+// stdin is read on first use, YAML also covers JSON.
+var stdin = newInput("stdin", YAML, stdinReader{})
+
+type stdinReader struct{}
+
+func (stdinReader) Read(p []byte) (int, error) {
+	return os.Stdin.Read(p)
+}
+
+// newInput returns a config file read once from r, so a reload gets the
+// same data.
+func newInput(name string, ft FileType, r io.Reader) configFile {
+	var (
+		once sync.Once
+		data []byte
+		err  error
+	)
+
+	return configFile{
+		Name:     name,
+		FileType: ft,
+		read: func(limit int64) ([]byte, error) {
+			once.Do(func() {
+				data, err = io.ReadAll(io.LimitReader(r, limit+1))
+			})
+
+			return data, err
+		},
+	}
+}
+
+// readInput reads a config file from memory or from a reader.
+func readInput(o *ConfigOptions, f configFile) ([]byte, error) {
+	// in-memory data has no detached signature
+	if len(o.PublicKeys) > 0 {
+		return nil, &SignatureError{Path: f.Name, Missing: true}
+	}
+
+	limit := maxFileSize(o)
+
+	data, err := f.read(limit)
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(data)) > limit {
+		return nil, &FileSizeError{Path: f.Name, Max: limit}
+	}
+
+	return data, nil
+}
+
+// openFS opens a file of the file system set by WithFS. The name must be
+// a valid, unrooted path of the file system.
+func openFS(o *ConfigOptions, name string) (fs.File, error) {
+	clean, err := checkPath(o, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return o.FS.Open(clean)
+}
+
+// readDir reads a directory of the OS or of the file system set by WithFS.
+func readDir(o *ConfigOptions, dir string) ([]fs.DirEntry, error) {
+	if o.FS != nil {
+		return fs.ReadDir(o.FS, dir)
+	}
+
+	return os.ReadDir(dir)
+}
+
+// statFile returns the file info of a file of the OS or of the file system
+// set by WithFS.
+func statFile(o *ConfigOptions, name string) (fs.FileInfo, error) {
+	if o.FS != nil {
+		return fs.Stat(o.FS, name)
+	}
+
+	return os.Stat(name)
+}
+
+// joinPath joins path elements with slashes for a file system set by
+// WithFS or with the separator of the OS.
+func joinPath(o *ConfigOptions, elem ...string) string {
+	if o.FS != nil {
+		return path.Join(elem...)
+	}
+
+	return filepath.Join(elem...)
+}
+
+// dirPath returns the directory of a file like joinPath.
+func dirPath(o *ConfigOptions, name string) string {
+	if o.FS != nil {
+		return path.Dir(name)
+	}
+
+	return filepath.Dir(name)
+}
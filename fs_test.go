@@ -0,0 +1,167 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package config_test
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zauberhaus/config"
+)
+
+func TestLoad_FS(t *testing.T) {
+	require.NoError(t, os.Chdir(t.TempDir()))
+
+	fsys := fstest.MapFS{
+		"app.yaml":           {Data: []byte("host: fs.host.com\nserver: !include server.yaml\n")},
+		"app.prod.yaml":      {Data: []byte("port: 9090\n")},
+		"server.yaml":        {Data: []byte("name: fs\n")},
+		"conf.d/10-tls.yaml": {Data: []byte("tls:\n  cert: cert.pem\n")},
+		"etc/app.json":       {Data: []byte(`{"host": "etc.host.com", "extends": "../base.yaml"}`)},
+		"base.yaml":          {Data: []byte("hosts: [a, b]\n")},
+		"etc/escape.yaml":    {Data: []byte("server: !include ../../server.yaml\n")},
+	}
+
+	t.Run("discovery", func(t *testing.T) {
+		cfg, f, err := config.Load[*TestLayeredConfig](
+			config.WithFS(fsys),
+			config.WithName("app"),
+			config.WithProfile("prod"),
+			config.WithDropInDir("conf.d"),
+		)
+		require.NoError(t, err)
+		assert.Equal(t, "app.yaml", f)
+		assert.Equal(t, "fs.host.com", cfg.Host)
+		assert.Equal(t, 9090, cfg.Port)
+		assert.Equal(t, "fs", cfg.Server.Name)
+		require.NotNil(t, cfg.TLS)
+		assert.Equal(t, "cert.pem", cfg.TLS.Cert)
+		assert.Equal(t, "key.pem", cfg.TLS.Key)
+	})
+
+	t.Run("paths", func(t *testing.T) {
+		cfg, files, err := config.LoadFiles[*TestLayeredConfig](config.WithFS(fsys), config.WithName("app"), config.WithPaths("etc"))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"base.yaml", "etc/app.json"}, files)
+		assert.Equal(t, "etc.host.com", cfg.Host)
+		assert.Equal(t, []string{"a", "b"}, cfg.Hosts)
+	})
+
+	t.Run("file", func(t *testing.T) {
+		cfg, _, err := config.Load[*TestLayeredConfig](config.WithFS(fsys), config.WithFile("./server.yaml"))
+		require.NoError(t, err)
+		assert.Equal(t, "localhost", cfg.Host)
+	})
+
+	t.Run("env", func(t *testing.T) {
+		t.Setenv("CONFIG", "etc/app.json")
+
+		cfg, _, err := config.Load[*TestLayeredConfig](config.WithFS(fsys))
+		require.NoError(t, err)
+		assert.Equal(t, "etc.host.com", cfg.Host)
+	})
+
+	t.Run("traversal", func(t *testing.T) {
+		for _, name := range []string{"../app.yaml", "/app.yaml", "etc/escape.yaml"} {
+			_, _, err := config.Load[*TestLayeredConfig](config.WithFS(fsys), config.WithFile(name))
+
+			var te *config.TraversalError
+			assert.True(t, errors.As(err, &te), name)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, _, err := config.Load[*TestLayeredConfig](config.WithFS(fsys), config.WithFile("missing.yaml"))
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("max size", func(t *testing.T) {
+		_, _, err := config.Load[*TestLayeredConfig](config.WithFS(fsys), config.WithFile("app.yaml"), config.WithMaxFileSize(10))
+		assert.EqualError(t, err, "file too large: 'app.yaml' (max 10 bytes)")
+	})
+}
+
+func TestLoad_Data(t *testing.T) {
+	require.NoError(t, os.Chdir(t.TempDir()))
+	require.NoError(t, os.WriteFile("server.yaml", []byte("name: included\n"), 0644))
+
+	t.Run("data", func(t *testing.T) {
+		cfg, f, err := config.Load[*TestLayeredConfig](config.WithData([]byte("host: data.host.com\nserver: !include server.yaml\ntls: {}\n"), config.YAML))
+		require.NoError(t, err)
+		assert.Equal(t, "data", f)
+		assert.Equal(t, "data.host.com", cfg.Host)
+		assert.Equal(t, "included", cfg.Server.Name)
+		require.NotNil(t, cfg.TLS)
+		assert.Equal(t, "key.pem", cfg.TLS.Key)
+	})
+
+	t.Run("reader", func(t *testing.T) {
+		cfg, f, err := config.Load[*TestLayeredConfig](config.WithReader(strings.NewReader("host = \"reader.host.com\"\n"), config.TOML))
+		require.NoError(t, err)
+		assert.Equal(t, "reader", f)
+		assert.Equal(t, "reader.host.com", cfg.Host)
+	})
+
+	t.Run("layered", func(t *testing.T) {
+		require.NoError(t, os.WriteFile("base.yaml", []byte("host: base.host.com\nport: 1234\n"), 0644))
+
+		cfg, files, err := config.LoadFiles[*TestLayeredConfig](
+			config.WithFile("base.yaml"),
+			config.WithData([]byte(`{"port": 4321}`), config.JSON),
+		)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"base.yaml", "data"}, files)
+		assert.Equal(t, "base.host.com", cfg.Host)
+		assert.Equal(t, 4321, cfg.Port)
+	})
+
+	t.Run("max size", func(t *testing.T) {
+		_, _, err := config.Load[*TestLayeredConfig](config.WithData([]byte("host: data.host.com\n"), config.YAML), config.WithMaxFileSize(10))
+		assert.EqualError(t, err, "file too large: 'data' (max 10 bytes)")
+	})
+
+	t.Run("signature", func(t *testing.T) {
+		pub, _, err := ed25519.GenerateKey(nil)
+		require.NoError(t, err)
+
+		_, _, err = config.Load[*TestLayeredConfig](config.WithData([]byte("host: data.host.com\n"), config.YAML), config.WithPublicKeys(pub))
+		assert.EqualError(t, err, "missing signature: 'data'")
+	})
+
+	t.Run("stdin", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "stdin.yaml")
+		require.NoError(t, os.WriteFile(file, []byte("host: stdin.host.com\n"), 0644))
+
+		in, err := os.Open(file)
+		require.NoError(t, err)
+
+		defer in.Close()
+
+		old := os.Stdin
+		os.Stdin = in
+
+		defer func() { os.Stdin = old }()
+
+		cfg, f, err := config.Load[*TestLayeredConfig](config.WithFile("-"))
+		require.NoError(t, err)
+		assert.Equal(t, "stdin", f)
+		assert.Equal(t, "stdin.host.com", cfg.Host)
+
+		// stdin is read once
+		t.Setenv("CONFIG", "-")
+
+		cfg, _, err = config.Load[*TestLayeredConfig]()
+		require.NoError(t, err)
+		assert.Equal(t, "stdin.host.com", cfg.Host)
+	})
+}
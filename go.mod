@@ -3,6 +3,7 @@ module github.com/zauberhaus/config
 go 1.25.5
 
 require (
+	github.com/BurntSushi/toml v1.6.0
 	github.com/creasty/defaults v1.8.0
 	github.com/gobeam/stringy v0.0.7
 	github.com/spf13/cobra v1.10.2
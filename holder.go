@@ -0,0 +1,207 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package config
+
+import (
+	"context"
+	"maps"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+)
+
+// Holder stores the current config and swaps it atomically on reload.
+type Holder[T any] struct {
+	value   atomic.Pointer[T]
+	options []Option
+	files   []configFile
+
+	lock        sync.Mutex
+	subscribers map[int]subscriber[T]
+	checks      []func(old *T, new *T) error
+	next        int
+}
+
+type subscriber[T any] struct {
+	prefixes []string
+	fn       func(old *T, new *T)
+}
+
+// NewHolder loads the config and returns a holder with the loaded value.
+func NewHolder[T any](options ...Option) (*Holder[T], error) {
+	h := &Holder[T]{
+		options:     options,
+		subscribers: map[int]subscriber[T]{},
+	}
+
+	cfg, files, _, err := load[T](options...)
+	if err != nil {
+		return nil, err
+	}
+
+	h.value.Store(cfg)
+	h.files = files
+
+	return h, nil
+}
+
+// Get returns the current config. The value must not be modified.
+func (h *Holder[T]) Get() *T {
+	return h.value.Load()
+}
+
+// Reload loads the config again and notifies the subscribers. If loading,
+// the validation or a check of the new value fails, the current value is
+// kept.
+func (h *Holder[T]) Reload() error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	cfg, files, _, err := load[T](h.options...)
+	if err != nil {
+		return err
+	}
+
+	for _, check := range h.checks {
+		err := check(h.value.Load(), cfg)
+		if err != nil {
+			return err
+		}
+	}
+
+	old := h.value.Swap(cfg)
+	h.files = files
+
+	changes := Diff(old, cfg)
+	if len(changes) == 0 {
+		return nil
+	}
+
+	for _, k := range slices.Sorted(maps.Keys(h.subscribers)) {
+		s := h.subscribers[k]
+		if s.matches(changes) {
+			s.fn(old, cfg)
+		}
+	}
+
+	return nil
+}
+
+// Check adds a check which is called with the current and the new value
+// before a reload is applied. An error rejects the new value.
+func (h *Holder[T]) Check(fn func(old *T, new *T) error) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.checks = append(h.checks, fn)
+}
+
+// Subscribe calls fn after a reload changed the config. With prefixes like
+// server or server.* fn is only called if a field below one of them
+// changed. The returned function removes the subscription. fn must not call
+// Reload, Check or Subscribe.
+func (h *Holder[T]) Subscribe(fn func(old *T, new *T), prefix ...string) func() {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	id := h.next
+	h.next++
+
+	prefixes := make([]string, 0, len(prefix))
+	for _, p := range prefix {
+		prefixes = append(prefixes, strings.TrimSuffix(strings.TrimSuffix(strings.ToLower(p), "*"), "."))
+	}
+
+	h.subscribers[id] = subscriber[T]{
+		prefixes: prefixes,
+		fn:       fn,
+	}
+
+	return func() {
+		h.lock.Lock()
+		defer h.lock.Unlock()
+
+		delete(h.subscribers, id)
+	}
+}
+
+// ReloadOnSignal reloads the config when the process receives one of the
+// signals, SIGHUP by default, until the context is done. Reload errors are
+// passed to onError.
+func (h *Holder[T]) ReloadOnSignal(ctx context.Context, onError func(error), sig ...os.Signal) {
+	if len(sig) == 0 {
+		sig = []os.Signal{syscall.SIGHUP}
+	}
+
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, sig...)
+
+	go func() {
+		defer signal.Stop(ch)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ch:
+				if err := h.Reload(); err != nil && onError != nil {
+					onError(err)
+				}
+			}
+		}
+	}()
+}
+
+// Watch reloads the config when one of the loaded files changes, until the
+// context is done. Reload errors are passed to onError.
+func (h *Holder[T]) Watch(ctx context.Context, onError func(error)) {
+	o := &ConfigOptions{}
+	for _, opt := range h.options {
+		opt.Set(o)
+	}
+
+	h.lock.Lock()
+	w := newWatcher(o, h.files)
+	h.lock.Unlock()
+
+	go w.run(ctx, func() ([]configFile, bool) {
+		if err := h.Reload(); err != nil {
+			if onError != nil {
+				onError(err)
+			}
+
+			return nil, false
+		}
+
+		h.lock.Lock()
+		defer h.lock.Unlock()
+
+		return h.files, true
+	})
+}
+
+func (s subscriber[T]) matches(changes []Change) bool {
+	if len(s.prefixes) == 0 {
+		return true
+	}
+
+	for _, c := range changes {
+		p := c.Path
+
+		for _, prefix := range s.prefixes {
+			if prefix == "" || p == prefix || strings.HasPrefix(p, prefix+".") || strings.HasPrefix(p, prefix+"[") ||
+				strings.HasPrefix(prefix, p+".") || strings.HasPrefix(prefix, p+"[") {
+				return true
+			}
+		}
+	}
+
+	return false
+}
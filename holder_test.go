@@ -0,0 +1,173 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package config_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zauberhaus/config"
+	"github.com/zauberhaus/config/pkg/validate"
+)
+
+type HolderConfig struct {
+	Server struct {
+		Host string `default:"localhost"`
+		Port int    `default:"8080" check:"max=65535"`
+	}
+	Log struct {
+		Level string `default:"info"`
+	}
+	Hosts []string
+}
+
+func TestHolder(t *testing.T) {
+	require.NoError(t, os.Chdir(t.TempDir()))
+
+	file := filepath.Join(t.TempDir(), "holder.yaml")
+	require.NoError(t, os.WriteFile(file, []byte("server:\n  port: 1000\n"), 0644))
+
+	h, err := config.NewHolder[HolderConfig](config.WithFile(file))
+	require.NoError(t, err)
+	assert.Equal(t, 1000, h.Get().Server.Port)
+
+	var all, server, log, hosts, both int
+
+	h.Subscribe(func(old, new *HolderConfig) {
+		all++
+	})
+
+	h.Subscribe(func(old, new *HolderConfig) {
+		server++
+		assert.NotEqual(t, old.Server, new.Server)
+	}, "server.*")
+
+	unsubscribe := h.Subscribe(func(old, new *HolderConfig) {
+		log++
+	}, "Log")
+
+	h.Subscribe(func(old, new *HolderConfig) {
+		hosts++
+	}, "hosts[0]")
+
+	h.Subscribe(func(old, new *HolderConfig) {
+		both++
+	}, "log", "hosts")
+
+	t.Run("reload", func(t *testing.T) {
+		old := h.Get()
+
+		require.NoError(t, os.WriteFile(file, []byte("server:\n  port: 2000\n"), 0644))
+		require.NoError(t, h.Reload())
+
+		assert.Equal(t, 2000, h.Get().Server.Port)
+		assert.Equal(t, 1000, old.Server.Port)
+		assert.Equal(t, []int{1, 1, 0, 0, 0}, []int{all, server, log, hosts, both})
+	})
+
+	t.Run("unchanged", func(t *testing.T) {
+		require.NoError(t, h.Reload())
+		assert.Equal(t, []int{1, 1, 0, 0, 0}, []int{all, server, log, hosts, both})
+	})
+
+	t.Run("scoped", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(file, []byte("server:\n  port: 2000\nlog:\n  level: debug\nhosts: [a]\n"), 0644))
+		require.NoError(t, h.Reload())
+		assert.Equal(t, []int{2, 1, 1, 1, 1}, []int{all, server, log, hosts, both})
+	})
+
+	t.Run("rollback on validation error", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(file, []byte("server:\n  port: 70000\n"), 0644))
+
+		err := h.Reload()
+		var errs validate.Errors
+		assert.ErrorAs(t, err, &errs)
+		assert.Equal(t, 2000, h.Get().Server.Port)
+		assert.Equal(t, "debug", h.Get().Log.Level)
+		assert.Equal(t, []int{2, 1, 1, 1, 1}, []int{all, server, log, hosts, both})
+	})
+
+	t.Run("check", func(t *testing.T) {
+		h.Check(func(old, new *HolderConfig) error {
+			if old.Server.Host != new.Server.Host {
+				return errors.New("host can't be changed")
+			}
+
+			return nil
+		})
+
+		require.NoError(t, os.WriteFile(file, []byte("server:\n  host: other\n"), 0644))
+		assert.EqualError(t, h.Reload(), "host can't be changed")
+		assert.Equal(t, "localhost", h.Get().Server.Host)
+	})
+
+	t.Run("unsubscribe", func(t *testing.T) {
+		unsubscribe()
+
+		require.NoError(t, os.WriteFile(file, []byte("server:\n  port: 2000\n"), 0644))
+		require.NoError(t, h.Reload())
+		assert.Equal(t, []int{3, 1, 1, 2, 2}, []int{all, server, log, hosts, both})
+	})
+
+	t.Run("any prefix", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(file, []byte("server:\n  port: 2000\nlog:\n  level: warn\n"), 0644))
+		require.NoError(t, h.Reload())
+		assert.Equal(t, []int{4, 1, 1, 2, 3}, []int{all, server, log, hosts, both})
+	})
+
+	t.Run("load error", func(t *testing.T) {
+		_, err := config.NewHolder[HolderConfig](config.WithFile(filepath.Join(t.TempDir(), "missing.yaml")))
+		assert.True(t, os.IsNotExist(err))
+	})
+}
+
+func TestHolder_Watch(t *testing.T) {
+	require.NoError(t, os.Chdir(t.TempDir()))
+
+	file := filepath.Join(t.TempDir(), "watch.yaml")
+	require.NoError(t, os.WriteFile(file, []byte("server:\n  port: 1000\n"), 0644))
+
+	h, err := config.NewHolder[HolderConfig](config.WithFile(file), config.WithPollInterval(10*time.Millisecond), config.WithDebounce(20*time.Millisecond))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changed := make(chan int, 1)
+	h.Subscribe(func(old, new *HolderConfig) {
+		changed <- new.Server.Port
+	}, "server")
+
+	errs := make(chan error, 1)
+	h.Watch(ctx, func(err error) {
+		errs <- err
+	})
+
+	require.NoError(t, os.WriteFile(file, []byte("server:\n  port: 70000\n"), 0644))
+
+	select {
+	case err := <-errs:
+		assert.ErrorContains(t, err, "must be at most 65535")
+		assert.Equal(t, 1000, h.Get().Server.Port)
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "timeout")
+	}
+
+	require.NoError(t, os.WriteFile(file, []byte("server:\n  port: 4000\n"), 0644))
+
+	select {
+	case port := <-changed:
+		assert.Equal(t, 4000, port)
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "timeout")
+	}
+}
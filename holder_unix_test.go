@@ -0,0 +1,65 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+//go:build unix
+
+package config_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zauberhaus/config"
+)
+
+func TestHolder_ReloadOnSignal(t *testing.T) {
+	require.NoError(t, os.Chdir(t.TempDir()))
+
+	file := filepath.Join(t.TempDir(), "signal.yaml")
+	require.NoError(t, os.WriteFile(file, []byte("server:\n  port: 1000\n"), 0644))
+
+	h, err := config.NewHolder[HolderConfig](config.WithFile(file))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changed := make(chan int, 1)
+	h.Subscribe(func(old, new *HolderConfig) {
+		changed <- new.Server.Port
+	})
+
+	var lock sync.Mutex
+	var errs []error
+
+	h.ReloadOnSignal(ctx, func(err error) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		errs = append(errs, err)
+	})
+
+	require.NoError(t, os.WriteFile(file, []byte("server:\n  port: 3000\n"), 0644))
+	require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGHUP))
+
+	select {
+	case port := <-changed:
+		assert.Equal(t, 3000, port)
+		assert.Equal(t, 3000, h.Get().Server.Port)
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "timeout")
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+	assert.Empty(t, errs)
+}
@@ -0,0 +1,124 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/zauberhaus/config/pkg/validate"
+)
+
+// Validator is called for every struct of the config after loading.
+type Validator = validate.Validator
+
+// AfterDefaults is called for every struct of the config after the default
+// values are set.
+type AfterDefaults interface {
+	AfterDefaults() error
+}
+
+// AfterFile is called for every struct of the config after the config files
+// are decoded, even if no file was found.
+type AfterFile interface {
+	AfterFile() error
+}
+
+// AfterLoad is called for every struct of the config after the env vars and
+// flags are applied and before the validation.
+type AfterLoad interface {
+	AfterLoad() error
+}
+
+func afterDefaults(cfg any) error {
+	return callHooks(reflect.ValueOf(cfg), "", func(v any) error {
+		if h, ok := v.(AfterDefaults); ok {
+			return h.AfterDefaults()
+		}
+
+		return nil
+	})
+}
+
+func afterFile(cfg any) error {
+	return callHooks(reflect.ValueOf(cfg), "", func(v any) error {
+		if h, ok := v.(AfterFile); ok {
+			return h.AfterFile()
+		}
+
+		return nil
+	})
+}
+
+func afterLoad(cfg any) error {
+	return callHooks(reflect.ValueOf(cfg), "", func(v any) error {
+		if h, ok := v.(AfterLoad); ok {
+			return h.AfterLoad()
+		}
+
+		return nil
+	})
+}
+
+// callHooks calls fn for all structs of the tree, nested structs first.
+func callHooks(val reflect.Value, path string, fn func(v any) error) error {
+	for val.Kind() == reflect.Pointer || val.Kind() == reflect.Interface {
+		if val.IsNil() {
+			return nil
+		}
+
+		val = val.Elem()
+	}
+
+	switch val.Kind() {
+	case reflect.Struct:
+		t := val.Type()
+
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+
+			err := callHooks(val.Field(i), join(path, strings.ToLower(field.Name)), fn)
+			if err != nil {
+				return err
+			}
+		}
+
+		var obj any
+		if val.CanAddr() {
+			obj = val.Addr().Interface()
+		} else {
+			obj = val.Interface()
+		}
+
+		err := fn(obj)
+		if err != nil && path != "" {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+
+		return err
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < val.Len(); i++ {
+			err := callHooks(val.Index(i), fmt.Sprintf("%s[%d]", path, i), fn)
+			if err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		iter := val.MapRange()
+		for iter.Next() {
+			err := callHooks(iter.Value(), fmt.Sprintf("%s[%v]", path, iter.Key()), fn)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
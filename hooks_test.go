@@ -0,0 +1,133 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package config_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zauberhaus/config"
+	"github.com/zauberhaus/config/pkg/validate"
+)
+
+type HookServer struct {
+	Host  string `default:"LocalHost"`
+	Port  int    `default:"8080"`
+	URL   string
+	Calls []string
+}
+
+func (s *HookServer) AfterDefaults() error {
+	s.Calls = append(s.Calls, "defaults:"+s.Host)
+	s.Host = strings.ToLower(s.Host)
+	return nil
+}
+
+func (s *HookServer) AfterFile() error {
+	s.Calls = append(s.Calls, "file:"+s.Host)
+	s.Host = strings.ToLower(s.Host)
+	return nil
+}
+
+func (s *HookServer) AfterLoad() error {
+	s.Calls = append(s.Calls, "load:"+s.Host)
+	s.Host = strings.ToLower(s.Host)
+	s.URL = fmt.Sprintf("http://%s:%d", s.Host, s.Port)
+	return nil
+}
+
+func (s *HookServer) Validate() error {
+	if s.Port == 8443 {
+		return errors.New("port 8443 is reserved")
+	}
+
+	return nil
+}
+
+type HookConfig struct {
+	Name    string
+	Server  HookServer
+	Backups []HookServer
+	Debug   *HookServer
+}
+
+func (c *HookConfig) Validate() error {
+	if c.Name == "invalid" {
+		return errors.New("invalid name")
+	}
+
+	return nil
+}
+
+type FailingHookServer struct {
+	Host string
+}
+
+func (s *FailingHookServer) AfterFile() error {
+	return errors.New("after file failed")
+}
+
+type FailingHookConfig struct {
+	Backups []FailingHookServer
+}
+
+func TestLoad_Hooks(t *testing.T) {
+	require.NoError(t, os.Chdir(t.TempDir()))
+
+	file := filepath.Join(t.TempDir(), "hooks.yaml")
+	require.NoError(t, os.WriteFile(file, []byte("server:\n  host: File.Host\nbackups:\n  - host: Backup.Host\n    port: 9000\n"), 0644))
+
+	t.Run("call order", func(t *testing.T) {
+		t.Setenv("HOOKS_SERVER_HOST", "Env.Host")
+
+		cfg, _, err := config.Load[*HookConfig](config.WithFile(file), config.WithName("hooks"))
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"defaults:LocalHost", "file:File.Host", "load:Env.Host"}, cfg.Server.Calls)
+		assert.Equal(t, "env.host", cfg.Server.Host)
+		assert.Equal(t, "http://env.host:8080", cfg.Server.URL)
+
+		require.Len(t, cfg.Backups, 1)
+		assert.Equal(t, []string{"file:Backup.Host", "load:backup.host"}, cfg.Backups[0].Calls)
+		assert.Equal(t, "http://backup.host:9000", cfg.Backups[0].URL)
+
+		assert.Nil(t, cfg.Debug)
+	})
+
+	t.Run("validator", func(t *testing.T) {
+		t.Setenv("HOOKS_NAME", "invalid")
+		t.Setenv("HOOKS_SERVER_PORT", "8443")
+
+		_, _, err := config.Load[*HookConfig](config.WithFile(file), config.WithName("hooks"))
+
+		var errs validate.Errors
+		require.ErrorAs(t, err, &errs)
+		require.Len(t, errs, 2)
+		assert.EqualError(t, errs[0], "server (HOOKS_SERVER): port 8443 is reserved")
+		assert.EqualError(t, errs[1], "invalid name")
+	})
+
+	t.Run("skip validation", func(t *testing.T) {
+		t.Setenv("HOOKS_NAME", "invalid")
+
+		_, _, err := config.Load[*HookConfig](config.WithFile(file), config.WithName("hooks"), config.SkipValidation)
+		assert.NoError(t, err)
+	})
+
+	t.Run("hook error", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "failing.yaml")
+		require.NoError(t, os.WriteFile(file, []byte("backups:\n  - host: backup\n"), 0644))
+
+		_, _, err := config.Load[*FailingHookConfig](config.WithFile(file))
+		assert.EqualError(t, err, "backups[0]: after file failed")
+	})
+}
@@ -0,0 +1,280 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+const (
+	IncludeTag = "!include"
+	ExtendsKey = "extends"
+	// JSONExtendsKey is an alternative to ExtendsKey for formats without
+	// a natural place for directives like JSON.
+	JSONExtendsKey = "$extends"
+)
+
+// expandFiles reads the files, resolves their !include tags and adds the
+// files they extend in front of them.
+func expandFiles(o *ConfigOptions, files []configFile) ([]configFile, string, error) {
+	var result []configFile
+
+	for _, f := range files {
+		tmp, name, err := expandFile(o, f, nil)
+		if err != nil {
+			return nil, name, err
+		}
+
+		result = append(result, tmp...)
+	}
+
+	return result, "", nil
+}
+
+func expandFile(o *ConfigOptions, f configFile, stack []string) ([]configFile, string, error) {
+	stack, err := push(stack, f.Name)
+	if err != nil {
+		return nil, f.Name, err
+	}
+
+	var data []byte
+	if f.read != nil {
+		data, err = readInput(o, f)
+	} else {
+		data, err = readFile(o, f.Name)
+	}
+
+	if err != nil {
+		return nil, f.Name, err
+	}
+
+	include := f.FileType == YAML && bytes.Contains(data, []byte(IncludeTag))
+	encrypted := bytes.Contains(data, []byte(encryptedPrefix))
+
+	if encrypted && f.FileType != YAML && f.FileType != JSON {
+		data, err = decryptData(o, f.FileType, data)
+		if err != nil {
+			return nil, f.Name, fmt.Errorf("decrypt %s: %w", f.Name, err)
+		}
+
+		f.rewritten = true
+	} else if include || encrypted {
+		var node yaml.Node
+
+		err := yaml.Unmarshal(data, &node)
+		if err != nil {
+			return nil, f.Name, err
+		}
+
+		if include {
+			f.Includes, err = includeNode(o, &node, baseDir(o, f), stack)
+			if err != nil {
+				return nil, f.Name, err
+			}
+		}
+
+		if encrypted || len(f.Includes) > 0 {
+			var key string
+
+			err = decryptNode(o, &node, "", &key)
+			if err != nil {
+				return nil, f.Name, fmt.Errorf("decrypt %s: %w", f.Name, err)
+			}
+		}
+
+		data, err = encodeNode(&node, f.FileType)
+		if err != nil {
+			return nil, f.Name, err
+		}
+
+		f.rewritten = true
+	}
+
+	f.Data = data
+
+	extends, err := extendsFiles(f)
+	if err != nil {
+		return nil, f.Name, err
+	}
+
+	var result []configFile
+
+	for _, v := range extends {
+		name, err := resolvePath(o, baseDir(o, f), v)
+		if err != nil {
+			return nil, f.Name, err
+		}
+
+		tmp, n, err := expandFile(o, configFile{Name: name, FileType: fileType(o, name)}, stack)
+		if err != nil {
+			return nil, n, err
+		}
+
+		result = append(result, tmp...)
+	}
+
+	return append(result, f), "", nil
+}
+
+// includeNode replaces all nodes tagged with !include by the content of the
+// referenced file and returns the names of the included files.
+func includeNode(o *ConfigOptions, node *yaml.Node, dir string, stack []string) ([]string, error) {
+	if node.Tag == IncludeTag {
+		if node.Kind != yaml.ScalarNode || node.Value == "" {
+			return nil, fmt.Errorf("%s requires a file name (line %d)", IncludeTag, node.Line)
+		}
+
+		name, err := resolvePath(o, dir, node.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		stack, err := push(stack, name)
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := readFile(o, name)
+		if err != nil {
+			return nil, err
+		}
+
+		var doc yaml.Node
+
+		switch ft := fileType(o, name); ft {
+		case YAML, JSON:
+			err = yaml.Unmarshal(data, &doc)
+		default:
+			var val any
+
+			err = decode(name, ft, data, &val)
+			if err == nil {
+				err = doc.Encode(val)
+			}
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("include %s: %w", name, err)
+		}
+
+		content := &doc
+		if doc.Kind == yaml.DocumentNode {
+			if len(doc.Content) == 0 {
+				content = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null"}
+			} else {
+				content = doc.Content[0]
+			}
+		}
+
+		includes, err := includeNode(o, content, dirPath(o, name), stack)
+		if err != nil {
+			return nil, err
+		}
+
+		*node = *content
+
+		return append(includes, name), nil
+	}
+
+	var includes []string
+
+	for _, c := range node.Content {
+		tmp, err := includeNode(o, c, dir, stack)
+		if err != nil {
+			return nil, err
+		}
+
+		includes = append(includes, tmp...)
+	}
+
+	return includes, nil
+}
+
+// extendsFiles returns the files referenced by the extends key of a file.
+func extendsFiles(f configFile) ([]string, error) {
+	if !bytes.Contains(f.Data, []byte(ExtendsKey)) {
+		return nil, nil
+	}
+
+	var m map[string]any
+
+	// decoding errors are reported when the file is decoded into the config
+	if err := decode(f.Name, f.FileType, f.Data, &m); err != nil {
+		return nil, nil
+	}
+
+	var result []string
+
+	for _, k := range []string{ExtendsKey, JSONExtendsKey} {
+		switch v := m[k].(type) {
+		case nil:
+		case string:
+			result = append(result, v)
+		case []any:
+			for _, e := range v {
+				txt, ok := e.(string)
+				if !ok {
+					return nil, fmt.Errorf("invalid %s value in %s: %v", k, f.Name, v)
+				}
+
+				result = append(result, txt)
+			}
+		default:
+			return nil, fmt.Errorf("invalid %s value in %s: %v", k, f.Name, v)
+		}
+	}
+
+	return result, nil
+}
+
+// resolvePath returns the path of an included file relative to the dir of
+// the including file.
+func resolvePath(o *ConfigOptions, dir string, name string) (string, error) {
+	// a file system set by WithFS is the root
+	if o.FS != nil {
+		return checkPath(o, path.Join(dir, name))
+	}
+
+	// without roots .. is checked before the name is joined with the dir
+	if len(o.Roots) == 0 && hasParent(name) {
+		return "", &TraversalError{Path: name}
+	}
+
+	if !filepath.IsAbs(name) {
+		name = filepath.Join(dir, name)
+	}
+
+	if _, err := checkPath(o, name); err != nil {
+		return "", err
+	}
+
+	return filepath.Clean(name), nil
+}
+
+// baseDir returns the directory for the files referenced by a file, the
+// working directory or the root of the file system for in-memory data.
+func baseDir(o *ConfigOptions, f configFile) string {
+	if f.read != nil {
+		return "."
+	}
+
+	return dirPath(o, f.Name)
+}
+
+func push(stack []string, name string) ([]string, error) {
+	if slices.Contains(stack, name) {
+		return nil, fmt.Errorf("include cycle: %s -> %s", strings.Join(stack, " -> "), name)
+	}
+
+	return append(slices.Clone(stack), name), nil
+}
@@ -0,0 +1,182 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Location is a default location searched for config files. Locations can
+// be combined like WorkingDir|HomeDir.
+type Location int
+
+const (
+	// WorkingDir is the current working directory.
+	WorkingDir Location = 1 << iota
+	// XDGConfigHome is $XDG_CONFIG_HOME/<name>, by default ~/.config/<name>.
+	XDGConfigHome
+	// DotDir is ~/.<name>.
+	DotDir
+	// HomeDir is the home directory.
+	HomeDir
+	// XDGConfigDirs are the directories $XDG_CONFIG_DIRS/<name>, by default
+	// /etc/xdg/<name>.
+	XDGConfigDirs
+	// SystemDir is /etc/<name>.
+	SystemDir
+
+	// UserLocations are the locations in the home directory.
+	UserLocations = XDGConfigHome | DotDir | HomeDir
+	// SystemLocations are the system-wide locations.
+	SystemLocations = XDGConfigDirs | SystemDir
+	AllLocations    = WorkingDir | UserLocations | SystemLocations
+)
+
+const (
+	defaultConfigHome = ".config"
+	defaultConfigDirs = "/etc/xdg"
+	systemConfigDir   = "/etc"
+)
+
+// searchPath is a directory searched for config files with the base names
+// of the files.
+type searchPath struct {
+	dir   string
+	names []string
+}
+
+// searchPaths returns the directories to search for config files, ordered
+// from highest to lowest priority. The directories of the application like
+// ~/.config/<name> are only searched with a name and contain <name>.<ext>
+// or config.<ext>. In a file system set by WithFS the root is searched by
+// default.
+func searchPaths(o *ConfigOptions, named bool) ([]searchPath, error) {
+	names := []string{o.Name}
+
+	if o.FS != nil {
+		if len(o.Paths) == 0 {
+			return []searchPath{{dir: ".", names: names}}, nil
+		}
+
+		var paths []searchPath
+		for _, p := range o.Paths {
+			paths = append(paths, searchPath{dir: p, names: names})
+		}
+
+		return paths, nil
+	}
+
+	var paths []searchPath
+
+	for _, p := range o.Paths {
+		dir, ok, err := expandPath(p)
+		if err != nil {
+			return nil, err
+		}
+
+		// $UNSET/conf must not become /conf
+		if !ok {
+			continue
+		}
+
+		paths = append(paths, searchPath{dir: dir, names: names})
+	}
+
+	enabled := func(l Location) bool {
+		return o.DisabledLocations&l == 0
+	}
+
+	if enabled(WorkingDir) {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return nil, fmt.Errorf("get current index failed: %v", err)
+		}
+
+		paths = append(paths, searchPath{dir: cwd, names: names})
+	}
+
+	var home string
+
+	if o.DisabledLocations&UserLocations != UserLocations {
+		tmp, err := os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("get homedir failed: %v", err)
+		}
+
+		home = tmp
+	}
+
+	appNames := slices.Compact([]string{o.Name, "config"})
+
+	if named && enabled(XDGConfigHome) {
+		dir := os.Getenv("XDG_CONFIG_HOME")
+
+		// relative paths are invalid according to the spec
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(home, defaultConfigHome)
+		}
+
+		paths = append(paths, searchPath{dir: filepath.Join(dir, o.Name), names: appNames})
+	}
+
+	if named && enabled(DotDir) {
+		paths = append(paths, searchPath{dir: filepath.Join(home, "."+o.Name), names: appNames})
+	}
+
+	if enabled(HomeDir) {
+		paths = append(paths, searchPath{dir: home, names: names})
+	}
+
+	if named && enabled(XDGConfigDirs) {
+		dirs := os.Getenv("XDG_CONFIG_DIRS")
+		if dirs == "" {
+			dirs = defaultConfigDirs
+		}
+
+		for _, dir := range filepath.SplitList(dirs) {
+			if filepath.IsAbs(dir) {
+				paths = append(paths, searchPath{dir: filepath.Join(dir, o.Name), names: appNames})
+			}
+		}
+	}
+
+	if named && enabled(SystemDir) {
+		paths = append(paths, searchPath{dir: filepath.Join(systemConfigDir, o.Name), names: appNames})
+	}
+
+	return paths, nil
+}
+
+// expandPath replaces a leading ~ by the home directory and expands
+// environment variables like $HOME or ${HOME}. It returns false if a
+// variable isn't set.
+func expandPath(p string) (string, bool, error) {
+	if p == "~" || strings.HasPrefix(p, "~/") || strings.HasPrefix(p, "~"+string(filepath.Separator)) {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", false, fmt.Errorf("get homedir failed: %v", err)
+		}
+
+		p = home + p[1:]
+	}
+
+	ok := true
+
+	p = os.Expand(p, func(name string) string {
+		val, found := os.LookupEnv(name)
+		if !found {
+			ok = false
+		}
+
+		return val
+	})
+
+	return p, ok, nil
+}
@@ -0,0 +1,113 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zauberhaus/config"
+)
+
+func TestLoad_Locations(t *testing.T) {
+	require.NoError(t, os.Chdir(t.TempDir()))
+
+	home := t.TempDir()
+	xdgHome := t.TempDir()
+	xdgDirs := t.TempDir()
+
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", xdgHome)
+	t.Setenv("XDG_CONFIG_DIRS", filepath.Join(xdgDirs, "missing")+string(filepath.ListSeparator)+xdgDirs)
+
+	write := func(t *testing.T, name string, content string) string {
+		require.NoError(t, os.MkdirAll(filepath.Dir(name), 0755))
+		require.NoError(t, os.WriteFile(name, []byte(content), 0644))
+
+		return name
+	}
+
+	system := write(t, filepath.Join(xdgDirs, "loc-app", "config.yaml"), "host: system.host.com\nport: 1000\n")
+	homeFile := write(t, filepath.Join(home, "loc-app.yaml"), "port: 2000\n")
+	dot := write(t, filepath.Join(home, ".loc-app", "config.toml"), "port = 3000\n")
+	user := write(t, filepath.Join(xdgHome, "loc-app", "loc-app.json"), `{"port": 4000}`)
+
+	t.Run("layered", func(t *testing.T) {
+		cfg, files, err := config.LoadFiles[*TestLayeredConfig](config.WithName("loc-app"), config.Layered)
+		require.NoError(t, err)
+		assert.Equal(t, []string{system, homeFile, dot, user}, files)
+		assert.Equal(t, "system.host.com", cfg.Host)
+		assert.Equal(t, 4000, cfg.Port)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		cfg, f, err := config.Load[*TestLayeredConfig](config.WithName("loc-app"), config.WithLocation(config.XDGConfigHome|config.DotDir, false))
+		require.NoError(t, err)
+		assert.Equal(t, homeFile, f)
+		assert.Equal(t, 2000, cfg.Port)
+
+		cfg, f, err = config.Load[*TestLayeredConfig](
+			config.WithName("loc-app"),
+			config.WithLocation(config.AllLocations, false),
+			config.WithLocation(config.SystemLocations, true),
+		)
+		require.NoError(t, err)
+		assert.Equal(t, system, f)
+		assert.Equal(t, 1000, cfg.Port)
+	})
+
+	t.Run("default xdg home", func(t *testing.T) {
+		t.Setenv("XDG_CONFIG_HOME", "relative")
+
+		file := write(t, filepath.Join(home, ".config", "loc-app", "config.yaml"), "port: 5000\n")
+		defer os.Remove(file)
+
+		cfg, f, err := config.Load[*TestLayeredConfig](config.WithName("loc-app"))
+		require.NoError(t, err)
+		assert.Equal(t, file, f)
+		assert.Equal(t, 5000, cfg.Port)
+	})
+
+	t.Run("unnamed", func(t *testing.T) {
+		write(t, filepath.Join(xdgHome, "config", "config.yaml"), "port: 6000\n")
+
+		_, f, err := config.Load[*TestLayeredConfig]()
+		require.NoError(t, err)
+		assert.Empty(t, f)
+	})
+
+	t.Run("expand paths", func(t *testing.T) {
+		tilde := write(t, filepath.Join(home, "conf", "loc-app.yaml"), "port: 7000\n")
+		env := write(t, filepath.Join(xdgDirs, "env", "loc-app.yaml"), "port: 8000\n")
+
+		t.Setenv("LOC_APP_DIR", filepath.Join(xdgDirs, "env"))
+
+		cfg, files, err := config.LoadFiles[*TestLayeredConfig](
+			config.WithName("loc-app"),
+			config.WithPaths("~/conf", "${LOC_APP_DIR}"),
+			config.WithLocation(config.AllLocations, false),
+			config.Layered,
+		)
+		require.NoError(t, err)
+		assert.Equal(t, []string{env, tilde}, files)
+		assert.Equal(t, 7000, cfg.Port)
+
+		// paths with unset variables are skipped instead of resolved to
+		// the current or the root directory
+		require.NoError(t, os.Chdir(filepath.Join(home, "conf")))
+
+		_, f, err := config.Load[*TestLayeredConfig](
+			config.WithName("loc-app"),
+			config.WithPaths("$LOC_APP_UNSET", "${LOC_APP_UNSET}/conf"),
+			config.WithLocation(config.AllLocations, false),
+		)
+		require.NoError(t, err)
+		assert.Empty(t, f)
+	})
+}
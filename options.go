@@ -6,20 +6,53 @@
 package config
 
 import (
+	"context"
+	"crypto/ed25519"
+	"io"
+	"io/fs"
+	"time"
+
 	"github.com/zauberhaus/config/pkg/flags"
 	"github.com/zauberhaus/config/pkg/index"
 )
 
 type ConfigOptions struct {
 	File       string
+	Files      []string
 	FileType   FileType
 	Name       string
 	Paths      []string
 	Strict     bool
+	Layered    bool
+	DropInDir  string
+	Profile    string
 	Index      index.Index
 	Flags      *flags.Flags
 	Extensions []Extension
 	Replacer   map[string]string
+
+	SkipValidation bool
+	Precedence     []Stage
+	Sources        []Source
+	Context        context.Context
+	PollInterval   time.Duration
+	Debounce       time.Duration
+
+	SecretResolvers      map[string]SecretResolver
+	ResolveAllReferences bool
+	EncryptionKey        string
+	EncryptionKeyFile    string
+	Roots                []string
+	SymlinkPolicy        SymlinkPolicy
+	MaxFileSize          int64
+	PermissionPolicy     PermissionPolicy
+	OnWarning            func(error)
+	PublicKeys           []ed25519.PublicKey
+	FS                   fs.FS
+	DisabledLocations    Location
+
+	inputs []configFile
+	report Report
 }
 
 type Option interface {
@@ -38,12 +71,198 @@ func WithFile(val string) Option {
 	})
 }
 
+// WithFiles loads all files in the given order, later files override
+// values of earlier ones.
+func WithFiles(val ...string) Option {
+	return optionFunc(func(o *ConfigOptions) {
+		o.Files = val
+	})
+}
+
+// WithDropInDir loads all supported files of a directory in lexical order
+// on top of the config file.
+func WithDropInDir(val string) Option {
+	return optionFunc(func(o *ConfigOptions) {
+		o.DropInDir = val
+	})
+}
+
+// WithProfile loads overlays like config.<profile>.yaml after the config
+// files. Without this option the profile is taken from the env var
+// <NAME>_PROFILE.
+func WithProfile(val string) Option {
+	return optionFunc(func(o *ConfigOptions) {
+		o.Profile = val
+	})
+}
+
+// WithPrecedence defines which sources are applied in which order, from
+// lowest to highest precedence. Default values are always set first, stages
+// missing in the list are skipped.
+func WithPrecedence(val ...Stage) Option {
+	return optionFunc(func(o *ConfigOptions) {
+		o.Precedence = val
+	})
+}
+
+// WithSource adds custom sources of config values. They are applied after
+// the config files unless the precedence defines another order.
+func WithSource(val ...Source) Option {
+	return optionFunc(func(o *ConfigOptions) {
+		o.Sources = append(o.Sources, val...)
+	})
+}
+
+// WithContext sets the context passed to the custom sources and secret
+// resolvers.
+func WithContext(ctx context.Context) Option {
+	return optionFunc(func(o *ConfigOptions) {
+		o.Context = ctx
+	})
+}
+
+// WithSecretResolver adds a resolver for string values starting with the
+// prefix like vault: or vault://. It replaces a builtin resolver with the
+// same prefix, nil disables the prefix.
+func WithSecretResolver(prefix string, r SecretResolver) Option {
+	return optionFunc(func(o *ConfigOptions) {
+		if o.SecretResolvers == nil {
+			o.SecretResolvers = map[string]SecretResolver{}
+		}
+
+		o.SecretResolvers[prefix] = r
+	})
+}
+
+// WithEncryptionKey sets the base64 encoded key to decrypt ENC[...] values
+// in config files.
+func WithEncryptionKey(key string) Option {
+	return optionFunc(func(o *ConfigOptions) {
+		o.EncryptionKey = key
+	})
+}
+
+// WithEncryptionKeyFile reads the key to decrypt ENC[...] values from a
+// file.
+func WithEncryptionKeyFile(name string) Option {
+	return optionFunc(func(o *ConfigOptions) {
+		o.EncryptionKeyFile = name
+	})
+}
+
+// WithRoot confines all config files to the directories, including files
+// reached by symlinks, includes and the CONFIG env var.
+func WithRoot(dirs ...string) Option {
+	return optionFunc(func(o *ConfigOptions) {
+		o.Roots = append(o.Roots, dirs...)
+	})
+}
+
+// WithSymlinkPolicy defines if config files can be symlinks.
+func WithSymlinkPolicy(val SymlinkPolicy) Option {
+	return optionFunc(func(o *ConfigOptions) {
+		o.SymlinkPolicy = val
+	})
+}
+
+// WithMaxFileSize limits the size of config files, DefaultMaxFileSize is
+// used by default.
+func WithMaxFileSize(val int64) Option {
+	return optionFunc(func(o *ConfigOptions) {
+		o.MaxFileSize = val
+	})
+}
+
+// WithPermissionPolicy defines if config files with insecure permissions
+// are ignored, reported as warning or rejected.
+func WithPermissionPolicy(val PermissionPolicy) Option {
+	return optionFunc(func(o *ConfigOptions) {
+		o.PermissionPolicy = val
+	})
+}
+
+// WithWarningHandler sets the function called with the warnings, they are
+// logged with slog by default.
+func WithWarningHandler(fn func(error)) Option {
+	return optionFunc(func(o *ConfigOptions) {
+		o.OnWarning = fn
+	})
+}
+
+// WithPublicKeys requires a detached signature like config.yaml.sig for
+// every config file, which must be valid for one of the keys.
+func WithPublicKeys(keys ...ed25519.PublicKey) Option {
+	return optionFunc(func(o *ConfigOptions) {
+		o.PublicKeys = append(o.PublicKeys, keys...)
+	})
+}
+
+// WithFS reads all config files from the file system like an embed.FS
+// instead of the OS. The paths are slash separated and relative to the
+// root of fsys, the files are searched in the root by default.
+func WithFS(fsys fs.FS) Option {
+	return optionFunc(func(o *ConfigOptions) {
+		o.FS = fsys
+	})
+}
+
+// WithReader loads a config file of the file type from r. The reader is
+// read once, a reload uses the same data. Like WithFile it disables the
+// search for config files.
+func WithReader(r io.Reader, fileType FileType) Option {
+	f := newInput("reader", fileType, r)
+
+	return optionFunc(func(o *ConfigOptions) {
+		o.inputs = append(o.inputs, f)
+	})
+}
+
+// WithData loads a config file of the file type from memory.
+func WithData(data []byte, fileType FileType) Option {
+	return optionFunc(func(o *ConfigOptions) {
+		o.inputs = append(o.inputs, configFile{
+			Name:     "data",
+			FileType: fileType,
+			read: func(int64) ([]byte, error) {
+				return data, nil
+			},
+		})
+	})
+}
+
+// WithPollInterval sets how often Watch checks the files for changes.
+func WithPollInterval(val time.Duration) Option {
+	return optionFunc(func(o *ConfigOptions) {
+		o.PollInterval = val
+	})
+}
+
+// WithDebounce sets how long Watch waits for further changes before the
+// config is reloaded.
+func WithDebounce(val time.Duration) Option {
+	return optionFunc(func(o *ConfigOptions) {
+		o.Debounce = val
+	})
+}
+
 func WithPaths(val ...string) Option {
 	return optionFunc(func(o *ConfigOptions) {
 		o.Paths = val
 	})
 }
 
+// WithLocation turns the search for config files in the default locations
+// on or off, all locations are searched by default.
+func WithLocation(loc Location, enabled bool) Option {
+	return optionFunc(func(o *ConfigOptions) {
+		if enabled {
+			o.DisabledLocations &^= loc
+		} else {
+			o.DisabledLocations |= loc
+		}
+	})
+}
+
 func WithName(val string) Option {
 	return optionFunc(func(o *ConfigOptions) {
 		o.Name = val
@@ -88,3 +307,21 @@ func WithExtensions(val []Extension) Option {
 var Strict Option = optionFunc(func(o *ConfigOptions) {
 	o.Strict = true
 })
+
+// Layered merges all config files found in the search paths instead of
+// loading only the first one.
+var Layered Option = optionFunc(func(o *ConfigOptions) {
+	o.Layered = true
+})
+
+// ResolveAllReferences resolves secret references like env:NAME in all
+// string fields instead of only in fields tagged with secret:"true" and
+// Secret values.
+var ResolveAllReferences Option = optionFunc(func(o *ConfigOptions) {
+	o.ResolveAllReferences = true
+})
+
+// SkipValidation disables the checks of the validate struct tags.
+var SkipValidation Option = optionFunc(func(o *ConfigOptions) {
+	o.SkipValidation = true
+})
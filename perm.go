@@ -0,0 +1,70 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package config
+
+import (
+	"fmt"
+	"io/fs"
+	"log/slog"
+)
+
+type PermissionPolicy int
+
+const (
+	// IgnorePermissions doesn't check the permissions of config files.
+	IgnorePermissions PermissionPolicy = iota
+	// WarnPermissions passes a PermissionError for insecure files to the
+	// warning handler and loads them anyway.
+	WarnPermissions
+	// EnforcePermissions rejects insecure files with a PermissionError.
+	EnforcePermissions
+)
+
+// PermissionError is returned for an insecure config file. Path is the file
+// or its directory and Mode the mode of this path.
+type PermissionError struct {
+	Path   string
+	Mode   fs.FileMode
+	Reason string
+}
+
+func (e *PermissionError) Error() string {
+	return fmt.Sprintf("insecure permissions: '%s' (%v): %s", e.Path, e.Mode, e.Reason)
+}
+
+// checkPermissions applies the permission policy to an opened config file.
+func checkPermissions(o *ConfigOptions, name string, fi fs.FileInfo) error {
+	if o.PermissionPolicy == IgnorePermissions {
+		return nil
+	}
+
+	err := insecure(name, fi, hasSecrets(o))
+	if err == nil {
+		return nil
+	}
+
+	if o.PermissionPolicy == EnforcePermissions {
+		return err
+	}
+
+	if o.OnWarning != nil {
+		o.OnWarning(err)
+	} else {
+		slog.Warn("insecure config file", "error", err)
+	}
+
+	return nil
+}
+
+func hasSecrets(o *ConfigOptions) bool {
+	for _, item := range o.Index {
+		if item.Secret {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,18 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+//go:build !unix
+
+package config
+
+import (
+	"io/fs"
+)
+
+// insecure doesn't check anything, the permission bits aren't meaningful
+// on this platform.
+func insecure(name string, fi fs.FileInfo, secrets bool) error {
+	return nil
+}
@@ -0,0 +1,46 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+//go:build unix
+
+package config
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+// insecure returns a PermissionError if the file is writable by others,
+// owned by another user, in a directory writable by others or readable by
+// others while the config contains secrets.
+func insecure(name string, fi fs.FileInfo, secrets bool) error {
+	mode := fi.Mode()
+
+	if st, ok := fi.Sys().(*syscall.Stat_t); ok {
+		if uid := int(st.Uid); uid != 0 && uid != os.Geteuid() {
+			return &PermissionError{Path: name, Mode: mode, Reason: fmt.Sprintf("owned by uid %d", uid)}
+		}
+	}
+
+	if mode.Perm()&0o022 != 0 {
+		return &PermissionError{Path: name, Mode: mode, Reason: "writable by group or others"}
+	}
+
+	dir := filepath.Dir(name)
+
+	// sticky directories like /tmp don't allow to replace files of others
+	if di, err := os.Stat(dir); err == nil && di.Mode().Perm()&0o002 != 0 && di.Mode()&fs.ModeSticky == 0 {
+		return &PermissionError{Path: dir, Mode: di.Mode(), Reason: "directory writable by others"}
+	}
+
+	if secrets && mode.Perm()&0o004 != 0 {
+		return &PermissionError{Path: name, Mode: mode, Reason: "readable by others, but the config contains secrets"}
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+//go:build unix
+
+package config_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zauberhaus/config"
+)
+
+type PermTestConfig struct {
+	Host     string
+	Password string `secret:"true"`
+}
+
+func TestLoad_Permissions(t *testing.T) {
+	tempDir := t.TempDir()
+	require.NoError(t, os.Chdir(tempDir))
+
+	write := func(t *testing.T, name string, mode os.FileMode) string {
+		file := filepath.Join(tempDir, name)
+		require.NoError(t, os.WriteFile(file, []byte("host: localhost\n"), 0600))
+		require.NoError(t, os.Chmod(file, mode))
+
+		return file
+	}
+
+	t.Run("secure", func(t *testing.T) {
+		file := write(t, "secure.yaml", 0600)
+
+		_, _, err := config.Load[*PermTestConfig](config.WithFile(file), config.WithPermissionPolicy(config.EnforcePermissions))
+		assert.NoError(t, err)
+	})
+
+	t.Run("ignore", func(t *testing.T) {
+		file := write(t, "ignore.yaml", 0666)
+
+		_, _, err := config.Load[*PermTestConfig](config.WithFile(file))
+		assert.NoError(t, err)
+	})
+
+	t.Run("writable", func(t *testing.T) {
+		file := write(t, "writable.yaml", 0620)
+
+		_, _, err := config.Load[*TestLayeredConfig](config.WithFile(file), config.WithPermissionPolicy(config.EnforcePermissions))
+
+		var pe *config.PermissionError
+		require.True(t, errors.As(err, &pe))
+		assert.Equal(t, file, pe.Path)
+		assert.Equal(t, os.FileMode(0620), pe.Mode)
+		assert.EqualError(t, err, "insecure permissions: '"+file+"' (-rw--w----): writable by group or others")
+	})
+
+	t.Run("readable with secrets", func(t *testing.T) {
+		file := write(t, "readable.yaml", 0644)
+
+		_, _, err := config.Load[*TestLayeredConfig](config.WithFile(file), config.WithPermissionPolicy(config.EnforcePermissions))
+		assert.NoError(t, err)
+
+		_, _, err = config.Load[*PermTestConfig](config.WithFile(file), config.WithPermissionPolicy(config.EnforcePermissions))
+		assert.EqualError(t, err, "insecure permissions: '"+file+"' (-rw-r--r--): readable by others, but the config contains secrets")
+	})
+
+	t.Run("directory", func(t *testing.T) {
+		dir := filepath.Join(tempDir, "shared")
+		require.NoError(t, os.Mkdir(dir, 0755))
+		require.NoError(t, os.Chmod(dir, 0777))
+
+		file := filepath.Join(dir, "config.yaml")
+		require.NoError(t, os.WriteFile(file, []byte("host: localhost\n"), 0600))
+
+		_, _, err := config.Load[*PermTestConfig](config.WithFile(file), config.WithPermissionPolicy(config.EnforcePermissions))
+
+		var pe *config.PermissionError
+		require.True(t, errors.As(err, &pe))
+		assert.Equal(t, dir, pe.Path)
+		assert.Equal(t, "directory writable by others", pe.Reason)
+
+		// files of others can't be replaced in sticky directories
+		require.NoError(t, os.Chmod(dir, 0777|os.ModeSticky))
+
+		_, _, err = config.Load[*PermTestConfig](config.WithFile(file), config.WithPermissionPolicy(config.EnforcePermissions))
+		assert.NoError(t, err)
+	})
+
+	t.Run("owner", func(t *testing.T) {
+		if os.Geteuid() != 0 {
+			t.Skip("requires root")
+		}
+
+		file := write(t, "owner.yaml", 0600)
+		require.NoError(t, os.Chown(file, 1234, 1234))
+
+		_, _, err := config.Load[*PermTestConfig](config.WithFile(file), config.WithPermissionPolicy(config.EnforcePermissions))
+		assert.EqualError(t, err, "insecure permissions: '"+file+"' (-rw-------): owned by uid 1234")
+	})
+
+	t.Run("warn", func(t *testing.T) {
+		file := write(t, "warn.yaml", 0666)
+
+		var warnings []error
+
+		cfg, _, err := config.Load[*PermTestConfig](
+			config.WithFile(file),
+			config.WithPermissionPolicy(config.WarnPermissions),
+			config.WithWarningHandler(func(err error) {
+				warnings = append(warnings, err)
+			}),
+		)
+		require.NoError(t, err)
+		assert.Equal(t, "localhost", cfg.Host)
+
+		require.Len(t, warnings, 1)
+
+		var pe *config.PermissionError
+		assert.True(t, errors.As(warnings[0], &pe))
+	})
+}
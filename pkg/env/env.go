@@ -6,6 +6,8 @@
 package env
 
 import (
+	"fmt"
+	"io"
 	"maps"
 	"os"
 	"slices"
@@ -13,9 +15,19 @@ import (
 	"strings"
 
 	"github.com/zauberhaus/config/pkg/index"
+	"github.com/zauberhaus/config/pkg/merge"
 	"github.com/zauberhaus/lookup"
 )
 
+const (
+	// FileSuffix marks env vars like APP_DB_PASSWORD_FILE which contain the
+	// path of a file with the value.
+	FileSuffix = "_FILE"
+	// DefaultMaxFileSize limits the size of files referenced by a _FILE env
+	// var.
+	DefaultMaxFileSize = 1 << 20
+)
+
 func List[T any](value T, options ...Option) (map[string]string, error) {
 	o := &EnvOptions{}
 
@@ -55,10 +67,20 @@ func List[T any](value T, options ...Option) (map[string]string, error) {
 			key = strings.Trim(key, "_ \n\r\t")
 			key = strings.ToUpper(key)
 
-			if _, ok := o.Index.Find(key); ok {
-				value = strings.Trim(value, " \n\r\t")
-				m[orig] = value
+			item, ok := o.Index.Lookup(key)
+			if !ok {
+				name, found := strings.CutSuffix(key, FileSuffix)
+				if _, ok := o.Index.Lookup(name); !found || !ok {
+					continue
+				}
 			}
+
+			value = strings.Trim(value, " \n\r\t")
+			if item.Secret && value != "" {
+				value = index.Redacted
+			}
+
+			m[orig] = value
 		}
 	}
 
@@ -82,10 +104,15 @@ func Set[T any](value T, options ...Option) (T, error) {
 	}
 
 	m := make(map[string]string)
+	names := make(map[string]string)
+	files := make(map[string]string)
+	strategies := make(map[string]merge.Strategy)
+
 	for _, envVar := range os.Environ() {
 		if i := strings.Index(envVar, "="); i >= 0 {
 			key := envVar[:i]
 			value := envVar[i+1:]
+			orig := key
 
 			if len(o.Prefix) > 0 {
 				if !strings.HasPrefix(key, o.Prefix) {
@@ -98,10 +125,16 @@ func Set[T any](value T, options ...Option) (T, error) {
 			key = strings.Trim(key, "_ \n\r\t")
 			key = strings.ToUpper(key)
 
-			if k, ok := o.Index.Find(key); ok {
-				key = k
+			if item, ok := o.Index.Lookup(key); ok {
+				key = item.Path
+				strategies[key] = item.Merge
+			} else if item, ok := o.Index.Lookup(strings.TrimSuffix(key, FileSuffix)); ok && strings.HasSuffix(key, FileSuffix) {
+				strategies[item.Path] = item.Merge
+				files[item.Path] = orig
+
+				continue
 			} else {
-				if !o.Strict {
+				if !o.Strict || slices.Contains(o.Ignore, key) {
 					continue
 				}
 
@@ -111,7 +144,23 @@ func Set[T any](value T, options ...Option) (T, error) {
 			value = strings.Trim(value, " \n\r\t")
 
 			m[key] = value
+			names[key] = orig
+		}
+	}
+
+	for key, name := range files {
+		// a value set directly wins over the file
+		if _, ok := m[key]; ok {
+			continue
+		}
+
+		value, err := readFile(os.Getenv(name), o.MaxFileSize)
+		if err != nil {
+			return *new(T), fmt.Errorf("%s: %w", name, err)
 		}
+
+		m[key] = value
+		names[key] = name
 	}
 
 	keys := slices.Collect(maps.Keys(m))
@@ -120,7 +169,7 @@ func Set[T any](value T, options ...Option) (T, error) {
 	for _, k := range keys {
 		v := m[k]
 
-		_, err := lookup.Set(value, k, v)
+		_, err := merge.Set(value, k, v, strategies[k])
 		if err != nil {
 			if _, ok := err.(*lookup.NotFoundError); ok {
 				if !o.Strict {
@@ -130,11 +179,46 @@ func Set[T any](value T, options ...Option) (T, error) {
 
 			return *new(T), err
 		}
+
+		if o.Observer != nil {
+			val, err := lookup.Get(value, k)
+			if err != nil {
+				return *new(T), err
+			}
+
+			o.Observer(names[k], k, val)
+		}
 	}
 
 	return value, nil
 }
 
+// readFile returns the trimmed content of a file referenced by a _FILE env
+// var.
+func readFile(name string, limit int64) (string, error) {
+	if limit <= 0 {
+		limit = DefaultMaxFileSize
+	}
+
+	f, err := os.Open(strings.TrimSpace(name))
+	if err != nil {
+		return "", err
+	}
+
+	defer f.Close()
+
+	data, err := io.ReadAll(io.LimitReader(f, limit+1))
+	if err != nil {
+		return "", err
+	}
+
+	if int64(len(data)) > limit {
+		return "", fmt.Errorf("file too large: %s (max %d bytes)", f.Name(), limit)
+	}
+
+	return strings.TrimSpace(string(data)), nil
+}
+
 func Prefix(name string) string {
 	o := &EnvOptions{}
 	WithName(name).Set(o)
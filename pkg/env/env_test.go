@@ -7,6 +7,8 @@ package env_test
 
 import (
 	"net"
+	"os"
+	"path/filepath"
 	"reflect"
 	"slices"
 	"strings"
@@ -359,3 +361,144 @@ func TestSetEnv_NonStruct(t *testing.T) {
 	_, err := env.Set(&i)
 	assert.NoError(t, err)
 }
+
+func TestSetEnv_MergeStrategy(t *testing.T) {
+	type Config struct {
+		Hosts  []string          `merge:"append"`
+		Unique []string          `merge:"unique"`
+		Tags   map[string]string `merge:"merge"`
+		Names  []string
+	}
+
+	t.Setenv("APP_HOSTS", "c,d")
+	t.Setenv("APP_UNIQUE", "b,c")
+	t.Setenv("APP_TAGS", "b=2")
+	t.Setenv("APP_NAMES", "x")
+
+	cfg := &Config{
+		Hosts:  []string{"a", "b"},
+		Unique: []string{"a", "b"},
+		Tags:   map[string]string{"a": "1"},
+		Names:  []string{"a", "b"},
+	}
+
+	_, err := env.Set(cfg, env.WithName("APP"))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"a", "b", "c", "d"}, cfg.Hosts)
+	assert.Equal(t, []string{"a", "b", "c"}, cfg.Unique)
+	assert.Equal(t, map[string]string{"a": "1", "b": "2"}, cfg.Tags)
+	assert.Equal(t, []string{"x"}, cfg.Names)
+}
+
+func TestSetEnv_WithIgnore(t *testing.T) {
+	t.Setenv("APP_PROFILE", "prod")
+	t.Setenv("APP_SERVER_HOST", "myhost")
+
+	var cfg TestConfig
+	_, err := env.Set(&cfg, env.WithName("APP"), env.Strict)
+	assert.Error(t, err)
+
+	_, err = env.Set(&cfg, env.WithName("APP"), env.Strict, env.WithIgnore("PROFILE"))
+	require.NoError(t, err)
+	assert.Equal(t, "myhost", cfg.Server.Host)
+}
+
+func TestSetEnv_WithObserver(t *testing.T) {
+	t.Setenv("APP_SERVER_HOST", "myhost")
+	t.Setenv("APP_SERVER_PORT", "9000")
+
+	seen := map[string]any{}
+	names := map[string]string{}
+
+	var cfg TestConfig
+	_, err := env.Set(&cfg, env.WithName("APP"), env.WithObserver(func(name string, path string, value any) {
+		seen[path] = value
+		names[path] = name
+	}))
+	require.NoError(t, err)
+
+	assert.Equal(t, "myhost", seen["server.host"])
+	assert.Equal(t, "APP_SERVER_HOST", names["server.host"])
+	assert.Equal(t, "APP_SERVER_PORT", names["server.port"])
+}
+
+func TestSetEnv_ListSecret(t *testing.T) {
+	type Config struct {
+		Password string `secret:"true"`
+		Token    string `secret:"true"`
+		User     string
+	}
+
+	t.Setenv("PASSWORD", "s3cr3t")
+	t.Setenv("TOKEN", "")
+	t.Setenv("USER", "admin")
+
+	values, err := env.List(&Config{})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "******", values["PASSWORD"])
+		assert.Equal(t, "", values["TOKEN"])
+		assert.Equal(t, "admin", values["USER"])
+	}
+}
+
+func TestSetEnv_File(t *testing.T) {
+	type Config struct {
+		Db struct {
+			User     string
+			Password string `secret:"true"`
+			Port     int
+		}
+		CertFile string
+	}
+
+	dir := t.TempDir()
+
+	password := filepath.Join(dir, "password")
+	require.NoError(t, os.WriteFile(password, []byte("s3cr3t\n"), 0600))
+
+	port := filepath.Join(dir, "port")
+	require.NoError(t, os.WriteFile(port, []byte("5432"), 0600))
+
+	t.Setenv("APP_DB_PASSWORD_FILE", password)
+	t.Setenv("APP_DB_PORT_FILE", port)
+	t.Setenv("APP_DB_USER", "admin")
+	t.Setenv("APP_DB_USER_FILE", password)
+	t.Setenv("APP_CERT_FILE", "cert.pem")
+
+	var names []string
+
+	var cfg Config
+	_, err := env.Set(&cfg, env.WithName("APP"), env.Strict, env.WithObserver(func(name string, path string, value any) {
+		names = append(names, name)
+	}))
+	require.NoError(t, err)
+
+	assert.Equal(t, "s3cr3t", cfg.Db.Password)
+	assert.Equal(t, 5432, cfg.Db.Port)
+	assert.Equal(t, "admin", cfg.Db.User)
+	assert.Equal(t, "cert.pem", cfg.CertFile)
+	assert.ElementsMatch(t, []string{"APP_CERT_FILE", "APP_DB_PASSWORD_FILE", "APP_DB_PORT_FILE", "APP_DB_USER"}, names)
+
+	t.Run("list", func(t *testing.T) {
+		values, err := env.List(&cfg, env.WithName("APP"))
+		require.NoError(t, err)
+
+		assert.Equal(t, password, values["APP_DB_PASSWORD_FILE"])
+		assert.Equal(t, "", values["APP_DB_PASSWORD"])
+		assert.Equal(t, "admin", values["APP_DB_USER"])
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		t.Setenv("APP_DB_PASSWORD_FILE", filepath.Join(dir, "missing"))
+
+		_, err := env.Set(&Config{}, env.WithName("APP"))
+		assert.ErrorContains(t, err, "APP_DB_PASSWORD_FILE: open ")
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		_, err := env.Set(&Config{}, env.WithName("APP"), env.WithMaxFileSize(4))
+		assert.EqualError(t, err, "APP_DB_PASSWORD_FILE: file too large: "+password+" (max 4 bytes)")
+	})
+}
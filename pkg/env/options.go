@@ -16,6 +16,10 @@ type EnvOptions struct {
 	Strict   bool
 	Index    index.Index
 	Replacer map[string]string
+	Ignore   []string
+	Observer func(name string, path string, value any)
+
+	MaxFileSize int64
 }
 
 type Option interface {
@@ -65,3 +69,26 @@ func WithReplacer(val map[string]string) Option {
 		o.Replacer = val
 	})
 }
+
+// WithIgnore skips env vars which aren't part of the index without an error
+// in strict mode. The keys are given without prefix.
+func WithIgnore(keys ...string) Option {
+	return optionFunc(func(o *EnvOptions) {
+		o.Ignore = append(o.Ignore, keys...)
+	})
+}
+
+// WithObserver calls fn for every env var applied to the config with the
+// name of the var, the path of the field and the resulting value.
+func WithObserver(fn func(name string, path string, value any)) Option {
+	return optionFunc(func(o *EnvOptions) {
+		o.Observer = fn
+	})
+}
+
+// WithMaxFileSize limits the size of files referenced by _FILE env vars.
+func WithMaxFileSize(val int64) Option {
+	return optionFunc(func(o *EnvOptions) {
+		o.MaxFileSize = val
+	})
+}
@@ -13,12 +13,12 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"github.com/zauberhaus/config/pkg/index"
+	"github.com/zauberhaus/config/pkg/merge"
 	"github.com/zauberhaus/lookup"
 )
 
-type Secret interface {
-	Secret() any
-}
+// Secret is implemented by types which wrap a secret value.
+type Secret = index.Secret
 
 type Flag struct {
 	fs         *pflag.FlagSet
@@ -211,6 +211,19 @@ func SetFlags[T any](value T, f *Flags, options ...Option) error {
 		opt.Set(o)
 	}
 
+	if len(o.Index) == 0 {
+		o.Index = f.dict
+	}
+
+	if len(o.Index) == 0 {
+		d, err := index.New[T](nil)
+		if err != nil {
+			return err
+		}
+
+		o.Index = d
+	}
+
 	for k, v := range f.flags {
 		if v.flag.Changed {
 			val, err := v.getValue()
@@ -218,10 +231,24 @@ func SetFlags[T any](value T, f *Flags, options ...Option) error {
 				return err
 			}
 
-			_, err = lookup.Set(value, k, val)
+			strategy := merge.Default
+			if item, ok := o.Index.LookupPath(k); ok {
+				strategy = item.Merge
+			}
+
+			_, err = merge.Set(value, k, val, strategy)
 			if err != nil {
 				return err
 			}
+
+			if o.Observer != nil {
+				val, err := lookup.Get(value, k)
+				if err != nil {
+					return err
+				}
+
+				o.Observer(v.flag.Name, k, val)
+			}
 		}
 	}
 
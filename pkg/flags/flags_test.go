@@ -19,6 +19,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/zauberhaus/config/pkg/flags"
 	"github.com/zauberhaus/config/pkg/index"
+	"github.com/zauberhaus/config/pkg/merge"
 )
 
 type customValue string
@@ -596,3 +597,74 @@ func TestFlags_BindCmdFlagFunc(t *testing.T) {
 		assert.Contains(t, err.Error(), "source flag not found: non-existent -> my.target")
 	})
 }
+
+func TestSetFlags_MergeStrategy(t *testing.T) {
+	type FlagTestConfig struct {
+		Hosts []string `merge:"unique"`
+		Names []string
+	}
+
+	flagSet := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flagSet.StringSlice("hosts", nil, "hosts")
+	flagSet.StringSlice("names", nil, "names")
+
+	require.NoError(t, flagSet.Set("hosts", "b,c"))
+	require.NoError(t, flagSet.Set("names", "c"))
+
+	t.Run("index from type", func(t *testing.T) {
+		cfg := &FlagTestConfig{Hosts: []string{"a", "b"}, Names: []string{"a", "b"}}
+
+		fl := flags.NewFlagList(nil)
+		require.NoError(t, fl.BindFlag(flagSet, "Hosts", flagSet.Lookup("hosts")))
+		require.NoError(t, fl.BindFlag(flagSet, "Names", flagSet.Lookup("names")))
+
+		require.NoError(t, flags.SetFlags(cfg, fl))
+		assert.Equal(t, []string{"a", "b", "c"}, cfg.Hosts)
+		assert.Equal(t, []string{"c"}, cfg.Names)
+	})
+
+	t.Run("with index", func(t *testing.T) {
+		cfg := &FlagTestConfig{Hosts: []string{"a", "b"}, Names: []string{"a", "b"}}
+
+		idx := index.Index{
+			"HOSTS": {Path: "hosts", Type: reflect.TypeOf([]string{})},
+			"NAMES": {Path: "names", Type: reflect.TypeOf([]string{}), Merge: merge.Append},
+		}
+
+		fl := flags.NewFlagList(nil)
+		require.NoError(t, fl.BindFlag(flagSet, "Hosts", flagSet.Lookup("hosts")))
+		require.NoError(t, fl.BindFlag(flagSet, "Names", flagSet.Lookup("names")))
+
+		require.NoError(t, flags.SetFlags(cfg, fl, flags.WithIndex(idx)))
+		assert.Equal(t, []string{"b", "c"}, cfg.Hosts)
+		assert.Equal(t, []string{"a", "b", "c"}, cfg.Names)
+	})
+}
+
+func TestSetFlags_WithObserver(t *testing.T) {
+	type FlagTestConfig struct {
+		Host  string
+		Hosts []string `merge:"append"`
+	}
+
+	flagSet := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flagSet.String("host", "", "host")
+	flagSet.StringSlice("hosts", nil, "hosts")
+	require.NoError(t, flagSet.Set("hosts", "b"))
+
+	fl := flags.NewFlagList(nil)
+	require.NoError(t, fl.BindFlag(flagSet, "Host", flagSet.Lookup("host")))
+	require.NoError(t, fl.BindFlag(flagSet, "Hosts", flagSet.Lookup("hosts")))
+
+	seen := map[string]any{}
+	names := map[string]string{}
+
+	cfg := &FlagTestConfig{Hosts: []string{"a"}}
+	require.NoError(t, flags.SetFlags(cfg, fl, flags.WithObserver(func(name string, path string, value any) {
+		seen[path] = value
+		names[path] = name
+	})))
+
+	assert.Equal(t, map[string]any{"hosts": []string{"a", "b"}}, seen)
+	assert.Equal(t, "hosts", names["hosts"])
+}
@@ -5,7 +5,11 @@
 
 package flags
 
+import "github.com/zauberhaus/config/pkg/index"
+
 type FlagOptions struct {
+	Index    index.Index
+	Observer func(name string, path string, value any)
 }
 
 type Option interface {
@@ -17,3 +21,17 @@ type optionFunc func(o *FlagOptions)
 func (f optionFunc) Set(o *FlagOptions) {
 	f(o)
 }
+
+func WithIndex(val index.Index) Option {
+	return optionFunc(func(o *FlagOptions) {
+		o.Index = val
+	})
+}
+
+// WithObserver calls fn for every changed flag applied to the config with
+// the name of the flag, the path of the field and the resulting value.
+func WithObserver(fn func(name string, path string, value any)) Option {
+	return optionFunc(func(o *FlagOptions) {
+		o.Observer = fn
+	})
+}
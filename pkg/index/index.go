@@ -13,21 +13,37 @@ import (
 	"regexp"
 	"slices"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/gobeam/stringy"
+	"github.com/zauberhaus/config/pkg/merge"
 	"go.yaml.in/yaml/v3"
 )
 
+// Redacted replaces the values of secrets in all outputs.
+const Redacted = "******"
+
 var (
 	braces = regexp.MustCompile(`\[([^\]]*)\]`)
 	tm     = reflect.TypeFor[encoding.TextUnmarshaler]()
+	st     = reflect.TypeFor[Secret]()
 )
 
+// Secret is implemented by types which wrap a secret value.
+type Secret interface {
+	Secret() any
+}
+
 type Item struct {
 	Path     string
 	Type     reflect.Type
 	Optional bool
+	Merge    merge.Strategy
+	// Restart marks fields which can't be changed without a restart
+	Restart bool
+	// Secret marks fields which values are redacted in all outputs
+	Secret bool
 }
 
 type Index map[string]Item
@@ -54,7 +70,13 @@ func (v Index) String() string {
 
 	for _, k := range keys {
 		v := v[k]
-		items = append(items, map[string]any{k: map[string]any{v.Path: fmt.Sprintf("%v", v.Type)}})
+		t := fmt.Sprintf("%v", v.Type)
+
+		if v.Secret {
+			t += " (secret)"
+		}
+
+		items = append(items, map[string]any{k: map[string]any{v.Path: t}})
 	}
 
 	date, err := yaml.Marshal(items)
@@ -67,6 +89,16 @@ func (v Index) String() string {
 }
 
 func (v Index) Find(name string) (string, bool) {
+	if r, ok := v.Lookup(name); ok {
+		return r.Path, true
+	}
+
+	return "", false
+}
+
+// Lookup returns the item of a key, the path of the item contains the
+// indexes and map keys of the name.
+func (v Index) Lookup(name string) (Item, bool) {
 	var params []string
 
 	matches := braces.FindAllStringSubmatch(name, -1)
@@ -81,7 +113,47 @@ func (v Index) Find(name string) (string, bool) {
 			r.Path = strings.Replace(r.Path, "[]", "["+p+"]", 1)
 		}
 
-		return r.Path, true
+		return r, true
+	}
+
+	return Item{}, false
+}
+
+// LookupPath returns the item of a path, the path of the item contains the
+// indexes and map keys of the given path.
+func (v Index) LookupPath(path string) (Item, bool) {
+	name := braces.ReplaceAllString(path, "[]")
+
+	for _, r := range v {
+		if name == r.Path {
+			r.Path = path
+			return r, true
+		}
+	}
+
+	return Item{}, false
+}
+
+// Key returns the key of a path, the key contains the indexes and map keys
+// of the given path.
+func (v Index) Key(path string) (string, bool) {
+	var params []string
+
+	matches := braces.FindAllStringSubmatch(path, -1)
+	for _, m := range matches {
+		params = append(params, m[1])
+	}
+
+	name := braces.ReplaceAllString(path, "[]")
+
+	for k, r := range v {
+		if name == r.Path {
+			for _, p := range params {
+				k = strings.Replace(k, "[]", "["+p+"]", 1)
+			}
+
+			return k, true
+		}
 	}
 
 	return "", false
@@ -229,12 +301,51 @@ func collect(v reflect.Type, tag []string, path []string, skip bool, d map[strin
 
 					tag := append(tag, SnakeCase(env))
 					path := append(path, strings.ToLower(field.Name))
+					key := strings.Join(tag, "_")
+					name := strings.Join(path, ".")
 
 					tmp, err := collect(field.Type, tag, path, false, d)
 					if err != nil {
 						return tmp, err
 					}
 
+					if txt, ok := field.Tag.Lookup("merge"); ok {
+						s, err := merge.Parse(txt)
+						if err != nil {
+							return nil, fmt.Errorf("%w: %s", err, name)
+						}
+
+						if item, ok := tmp[key]; ok {
+							item.Merge = s
+							tmp[key] = item
+						}
+					}
+
+					if txt, ok := field.Tag.Lookup("secret"); ok {
+						if _, err := strconv.ParseBool(txt); err != nil {
+							return nil, fmt.Errorf("invalid secret tag: '%s': %s", txt, name)
+						}
+					}
+
+					// all fields of a secret are secrets too
+					if IsSecret(field) {
+						for k, item := range tmp {
+							item.Secret = true
+							tmp[k] = item
+						}
+					}
+
+					if txt, ok := field.Tag.Lookup("reload"); ok {
+						if txt != "restart" {
+							return nil, fmt.Errorf("invalid reload policy: '%s': %s", txt, name)
+						}
+
+						if item, ok := tmp[key]; ok {
+							item.Restart = true
+							tmp[key] = item
+						}
+					}
+
 					maps.Insert(m, maps.All(tmp))
 				}
 
@@ -253,6 +364,16 @@ func collect(v reflect.Type, tag []string, path []string, skip bool, d map[strin
 	return m, nil
 }
 
+// IsSecret returns true if the field has the tag `secret:"true"` or its type
+// implements Secret.
+func IsSecret(field reflect.StructField) bool {
+	if ok, _ := strconv.ParseBool(field.Tag.Get("secret")); ok {
+		return true
+	}
+
+	return field.Type.Implements(st) || reflect.PointerTo(field.Type).Implements(st)
+}
+
 func SnakeCase(s string) string {
 	if strings.ToUpper(s) == s || strings.ToLower(s) == s {
 		return s
@@ -14,6 +14,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/zauberhaus/config/pkg/index"
+	"github.com/zauberhaus/config/pkg/merge"
 )
 
 type IndexTestConfig struct {
@@ -301,3 +302,133 @@ func TestIndex_Replacer(t *testing.T) {
 	assert.True(t, idx.Exists("BAZ_BAR"))
 	assert.False(t, idx.Exists("FOO_BAR"))
 }
+
+func TestIndex_Merge(t *testing.T) {
+	type Config struct {
+		Hosts []string          `merge:"append"`
+		Tags  map[string]string `merge:"replace"`
+		Names []string
+	}
+
+	idx, err := index.New[Config](nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, merge.Append, idx["HOSTS"].Merge)
+	assert.Equal(t, merge.Default, idx["HOSTS[]"].Merge)
+	assert.Equal(t, merge.Replace, idx["TAGS"].Merge)
+	assert.Equal(t, merge.Default, idx["NAMES"].Merge)
+
+	t.Run("invalid", func(t *testing.T) {
+		type Config struct {
+			Hosts []string `merge:"concat"`
+		}
+
+		_, err := index.New[Config](nil)
+		assert.ErrorContains(t, err, "invalid merge strategy: 'concat': hosts")
+	})
+}
+
+func TestIndex_Lookup(t *testing.T) {
+	dict, err := index.New[IndexTestConfig](nil)
+	require.NoError(t, err)
+
+	item, ok := dict.Lookup("SERVER_SETTINGS[1]_TAGS[abc]")
+	if assert.True(t, ok) {
+		assert.Equal(t, "server.settings[1].tags[abc]", item.Path)
+		assert.Equal(t, reflect.TypeOf(""), item.Type)
+	}
+
+	item, ok = dict.LookupPath("server.settings[1].tags")
+	if assert.True(t, ok) {
+		assert.Equal(t, "server.settings[1].tags", item.Path)
+		assert.Equal(t, reflect.TypeOf(map[string]string{}), item.Type)
+	}
+
+	_, ok = dict.Lookup("UNKNOWN")
+	assert.False(t, ok)
+
+	_, ok = dict.LookupPath("unknown")
+	assert.False(t, ok)
+}
+
+func TestIndex_Key(t *testing.T) {
+	dict, err := index.New[IndexTestConfig](nil)
+	require.NoError(t, err)
+
+	key, ok := dict.Key("server.settings[1].tags[abc]")
+	if assert.True(t, ok) {
+		assert.Equal(t, "SERVER_SETTINGS[1]_TAGS[abc]", key)
+	}
+
+	_, ok = dict.Key("unknown")
+	assert.False(t, ok)
+}
+
+func TestIndex_Restart(t *testing.T) {
+	type Config struct {
+		Port   int `reload:"restart"`
+		Host   string
+		Server struct {
+			Dir string
+		} `reload:"restart"`
+	}
+
+	idx, err := index.New[Config](nil)
+	require.NoError(t, err)
+
+	assert.True(t, idx["PORT"].Restart)
+	assert.False(t, idx["HOST"].Restart)
+	assert.True(t, idx["SERVER"].Restart)
+	assert.False(t, idx["SERVER_DIR"].Restart)
+
+	t.Run("invalid", func(t *testing.T) {
+		type Config struct {
+			Port int `reload:"never"`
+		}
+
+		_, err := index.New[Config](nil)
+		assert.ErrorContains(t, err, "invalid reload policy: 'never': port")
+	})
+}
+
+type secretValue struct {
+	value string
+}
+
+func (s secretValue) Secret() any {
+	return s.value
+}
+
+func (s *secretValue) UnmarshalText(data []byte) error {
+	s.value = string(data)
+	return nil
+}
+
+func TestIndex_Secret(t *testing.T) {
+	type Config struct {
+		Password string `secret:"true"`
+		Token    secretValue
+		Host     string
+		Database struct {
+			User string
+		} `secret:"true"`
+	}
+
+	idx, err := index.New[Config](nil)
+	require.NoError(t, err)
+
+	assert.True(t, idx["PASSWORD"].Secret)
+	assert.True(t, idx["TOKEN"].Secret)
+	assert.False(t, idx["HOST"].Secret)
+	assert.True(t, idx["DATABASE_USER"].Secret)
+	assert.Contains(t, idx.String(), "password: string (secret)")
+
+	t.Run("invalid", func(t *testing.T) {
+		type Config struct {
+			Password string `secret:"maybe"`
+		}
+
+		_, err := index.New[Config](nil)
+		assert.ErrorContains(t, err, "invalid secret tag: 'maybe': password")
+	})
+}
@@ -0,0 +1,210 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package merge
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/zauberhaus/lookup"
+)
+
+// Strategy defines how a slice or map value is combined with the value
+// already set by a source with lower precedence.
+type Strategy int
+
+const (
+	// Default keeps the native behavior of the source: config files merge
+	// maps and replace slices, env vars and flags replace both.
+	Default Strategy = iota
+	// Replace replaces the existing value.
+	Replace
+	// Merge merges maps key by key and slices index by index.
+	Merge
+	// Append appends slice elements, maps are merged.
+	Append
+	// Unique appends slice elements which aren't already included, maps are merged.
+	Unique
+)
+
+var names = map[Strategy]string{
+	Default: "",
+	Replace: "replace",
+	Merge:   "merge",
+	Append:  "append",
+	Unique:  "unique",
+}
+
+func Parse(txt string) (Strategy, error) {
+	txt = strings.ToLower(strings.TrimSpace(txt))
+
+	for k, v := range names {
+		if v == txt {
+			return k, nil
+		}
+	}
+
+	return Default, fmt.Errorf("invalid merge strategy: '%s'", txt)
+}
+
+func (s Strategy) String() string {
+	if n, ok := names[s]; ok {
+		if n == "" {
+			return "default"
+		}
+
+		return n
+	}
+
+	return fmt.Sprintf("Strategy(%d)", int(s))
+}
+
+// Combine returns the combination of the old and the new value of a slice or
+// map. All other values are replaced by the new value.
+func Combine(s Strategy, old any, new any) any {
+	if s == Default || s == Replace || old == nil || new == nil {
+		return new
+	}
+
+	o := reflect.ValueOf(old)
+	n := reflect.ValueOf(new)
+
+	if o.Type() != n.Type() {
+		return new
+	}
+
+	switch n.Kind() {
+	case reflect.Slice:
+		if n.IsNil() {
+			return new
+		}
+
+		switch s {
+		case Merge:
+			result := reflect.MakeSlice(n.Type(), max(o.Len(), n.Len()), max(o.Len(), n.Len()))
+			reflect.Copy(result, o)
+			reflect.Copy(result, n)
+
+			return result.Interface()
+		case Append:
+			result := reflect.MakeSlice(n.Type(), 0, o.Len()+n.Len())
+			result = reflect.AppendSlice(result, o)
+			result = reflect.AppendSlice(result, n)
+
+			return result.Interface()
+		case Unique:
+			result := reflect.MakeSlice(n.Type(), 0, o.Len()+n.Len())
+
+			for _, v := range []reflect.Value{o, n} {
+				for i := 0; i < v.Len(); i++ {
+					e := v.Index(i)
+					if !contains(result, e) {
+						result = reflect.Append(result, e)
+					}
+				}
+			}
+
+			return result.Interface()
+		}
+
+	case reflect.Map:
+		if n.IsNil() {
+			return new
+		}
+
+		result := reflect.MakeMapWithSize(n.Type(), o.Len()+n.Len())
+
+		for _, v := range []reflect.Value{o, n} {
+			iter := v.MapRange()
+			for iter.Next() {
+				result.SetMapIndex(iter.Key(), iter.Value())
+			}
+		}
+
+		return result.Interface()
+	}
+
+	return new
+}
+
+// Clone returns a shallow copy of a slice or map, all other values are
+// returned as they are.
+func Clone(val any) any {
+	if val == nil {
+		return nil
+	}
+
+	v := reflect.ValueOf(val)
+
+	switch v.Kind() {
+	case reflect.Slice:
+		if v.IsNil() {
+			return val
+		}
+
+		result := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		reflect.Copy(result, v)
+
+		return result.Interface()
+	case reflect.Map:
+		if v.IsNil() {
+			return val
+		}
+
+		result := reflect.MakeMapWithSize(v.Type(), v.Len())
+
+		iter := v.MapRange()
+		for iter.Next() {
+			result.SetMapIndex(iter.Key(), iter.Value())
+		}
+
+		return result.Interface()
+	}
+
+	return val
+}
+
+// Set sets the value of a field and combines it with the current value
+// using the strategy.
+func Set(obj any, path string, value any, s Strategy) (any, error) {
+	if s == Default || s == Replace {
+		return lookup.Set(obj, path, value)
+	}
+
+	ok, err := lookup.Exists(obj, path)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return lookup.Set(obj, path, value)
+	}
+
+	old, err := lookup.Get(obj, path)
+	if err != nil {
+		return nil, err
+	}
+
+	old = Clone(old)
+
+	new, err := lookup.Set(obj, path, value)
+	if err != nil {
+		return nil, err
+	}
+
+	return lookup.Set(obj, path, Combine(s, old, new))
+}
+
+func contains(list reflect.Value, e reflect.Value) bool {
+	for i := 0; i < list.Len(); i++ {
+		if reflect.DeepEqual(list.Index(i).Interface(), e.Interface()) {
+			return true
+		}
+	}
+
+	return false
+}
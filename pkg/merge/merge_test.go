@@ -0,0 +1,133 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package merge_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zauberhaus/config/pkg/merge"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected merge.Strategy
+	}{
+		{"", merge.Default},
+		{"replace", merge.Replace},
+		{"Merge", merge.Merge},
+		{" append ", merge.Append},
+		{"unique", merge.Unique},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			s, err := merge.Parse(tt.value)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, s)
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := merge.Parse("concat")
+		assert.ErrorContains(t, err, "invalid merge strategy")
+	})
+}
+
+func TestStrategy_String(t *testing.T) {
+	assert.Equal(t, "default", merge.Default.String())
+	assert.Equal(t, "append", merge.Append.String())
+	assert.Equal(t, "Strategy(99)", merge.Strategy(99).String())
+}
+
+func TestCombine(t *testing.T) {
+	old := []string{"a", "b", "c"}
+	new := []string{"c", "d"}
+
+	tests := []struct {
+		name     string
+		strategy merge.Strategy
+		old      any
+		new      any
+		expected any
+	}{
+		{"default slice", merge.Default, old, new, new},
+		{"replace slice", merge.Replace, old, new, new},
+		{"merge slice", merge.Merge, old, new, []string{"c", "d", "c"}},
+		{"append slice", merge.Append, old, new, []string{"a", "b", "c", "c", "d"}},
+		{"unique slice", merge.Unique, old, new, []string{"a", "b", "c", "d"}},
+		{"unique with duplicates", merge.Unique, []int{1, 1}, []int{2, 2}, []int{1, 2}},
+		{"append to nil", merge.Append, nil, new, new},
+		{"append nil", merge.Append, old, []string(nil), []string(nil)},
+		{"replace map", merge.Replace, map[string]int{"a": 1}, map[string]int{"b": 2}, map[string]int{"b": 2}},
+		{"merge map", merge.Merge, map[string]int{"a": 1, "b": 1}, map[string]int{"b": 2}, map[string]int{"a": 1, "b": 2}},
+		{"append map", merge.Append, map[string]int{"a": 1}, map[string]int{"b": 2}, map[string]int{"a": 1, "b": 2}},
+		{"scalar", merge.Append, "a", "b", "b"},
+		{"type mismatch", merge.Append, []int{1}, []string{"a"}, []string{"a"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, merge.Combine(tt.strategy, tt.old, tt.new))
+		})
+	}
+}
+
+func TestClone(t *testing.T) {
+	s := []string{"a"}
+	c := merge.Clone(s).([]string)
+	c[0] = "b"
+	assert.Equal(t, "a", s[0])
+
+	m := map[string]int{"a": 1}
+	cm := merge.Clone(m).(map[string]int)
+	cm["a"] = 2
+	assert.Equal(t, 1, m["a"])
+
+	assert.Nil(t, merge.Clone(nil))
+	assert.Equal(t, 1, merge.Clone(1))
+	assert.Equal(t, []string(nil), merge.Clone([]string(nil)))
+}
+
+func TestSet(t *testing.T) {
+	type Config struct {
+		List []string
+		Tags map[string]string
+		Sub  *struct {
+			List []string
+		}
+	}
+
+	cfg := &Config{
+		List: []string{"a"},
+		Tags: map[string]string{"a": "1"},
+	}
+
+	_, err := merge.Set(cfg, "list", "b,a", merge.Unique)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, cfg.List)
+
+	_, err = merge.Set(cfg, "list", []string{"c"}, merge.Append)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, cfg.List)
+
+	_, err = merge.Set(cfg, "tags", map[string]string{"b": "2"}, merge.Merge)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"a": "1", "b": "2"}, cfg.Tags)
+
+	_, err = merge.Set(cfg, "list", "x", merge.Replace)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"x"}, cfg.List)
+
+	_, err = merge.Set(cfg, "sub.list", "a", merge.Append)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a"}, cfg.Sub.List)
+
+	_, err = merge.Set(cfg, "unknown", "a", merge.Append)
+	assert.Error(t, err)
+}
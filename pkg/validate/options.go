@@ -0,0 +1,55 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package validate
+
+import (
+	"github.com/zauberhaus/config/pkg/env"
+	"github.com/zauberhaus/config/pkg/flags"
+	"github.com/zauberhaus/config/pkg/index"
+)
+
+type ValidateOptions struct {
+	Prefix   string
+	Index    index.Index
+	Flags    *flags.Flags
+	Replacer map[string]string
+}
+
+type Option interface {
+	Set(*ValidateOptions)
+}
+
+type optionFunc func(o *ValidateOptions)
+
+func (f optionFunc) Set(o *ValidateOptions) {
+	f(o)
+}
+
+// WithName sets the env var prefix used in the error messages.
+func WithName(val string) Option {
+	return optionFunc(func(o *ValidateOptions) {
+		o.Prefix = env.Prefix(val)
+	})
+}
+
+func WithIndex(val index.Index) Option {
+	return optionFunc(func(o *ValidateOptions) {
+		o.Index = val
+	})
+}
+
+func WithReplacer(val map[string]string) Option {
+	return optionFunc(func(o *ValidateOptions) {
+		o.Replacer = val
+	})
+}
+
+// WithFlags adds the names of the bound flags to the error messages.
+func WithFlags(val *flags.Flags) Option {
+	return optionFunc(func(o *ValidateOptions) {
+		o.Flags = val
+	})
+}
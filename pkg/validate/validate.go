@@ -0,0 +1,425 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package validate
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zauberhaus/config/pkg/index"
+	"github.com/zauberhaus/lookup"
+)
+
+// Tag is the struct tag with the validation rules. It differs from the
+// validate tag of other validation packages, so their rules are ignored.
+const Tag = "check"
+
+var durationType = reflect.TypeFor[time.Duration]()
+
+// Validator is implemented by config types with custom checks. Validate
+// calls it for every struct in the config tree.
+type Validator interface {
+	Validate() error
+}
+
+// FieldError is a violation of a validation rule or the error returned by
+// a Validator.
+type FieldError struct {
+	Path    string
+	Env     string
+	Flag    string
+	Rule    string
+	Message string
+	Err     error
+}
+
+func (e *FieldError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+
+	var names []string
+
+	if e.Env != "" {
+		names = append(names, e.Env)
+	}
+
+	if e.Flag != "" {
+		names = append(names, "--"+e.Flag)
+	}
+
+	if len(names) > 0 {
+		return fmt.Sprintf("%s (%s): %s", e.Path, strings.Join(names, ", "), e.Message)
+	}
+
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// Errors contains all violations found by Validate.
+type Errors []*FieldError
+
+func (e Errors) Error() string {
+	lines := make([]string, 0, len(e))
+	for _, v := range e {
+		lines = append(lines, v.Error())
+	}
+
+	return "validation failed:\n  " + strings.Join(lines, "\n  ")
+}
+
+func (e Errors) Unwrap() []error {
+	result := make([]error, 0, len(e))
+	for _, v := range e {
+		result = append(result, v)
+	}
+
+	return result
+}
+
+type rule struct {
+	name  string
+	param string
+}
+
+// Validate checks all fields with a check tag like
+// `check:"required,min=1,max=65535"`, calls the Validator of all structs
+// and returns all violations as Errors.
+func Validate[T any](value T, options ...Option) error {
+	o := &ValidateOptions{}
+	for _, opt := range options {
+		opt.Set(o)
+	}
+
+	if len(o.Index) == 0 {
+		d, err := index.New[T](o.Replacer)
+		if err != nil {
+			return err
+		}
+
+		o.Index = d
+	}
+
+	v := &validator{o: o}
+
+	err := v.walk(reflect.ValueOf(value), "", false)
+	if err != nil {
+		return err
+	}
+
+	if len(v.errors) > 0 {
+		return v.errors
+	}
+
+	return nil
+}
+
+type validator struct {
+	o      *ValidateOptions
+	errors Errors
+}
+
+// walk checks all fields of the value, the values of secrets aren't included
+// in the messages.
+func (v *validator) walk(val reflect.Value, path string, secret bool) error {
+	for val.Kind() == reflect.Pointer || val.Kind() == reflect.Interface {
+		if val.IsNil() {
+			return nil
+		}
+
+		val = val.Elem()
+	}
+
+	switch val.Kind() {
+	case reflect.Struct:
+		defer v.validator(val, path)
+
+		t := val.Type()
+
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+
+			p := join(path, strings.ToLower(field.Name))
+			f := val.Field(i)
+			s := secret || index.IsSecret(field)
+
+			if tag, ok := field.Tag.Lookup(Tag); ok {
+				err := v.check(f, p, tag, s)
+				if err != nil {
+					return err
+				}
+			}
+
+			err := v.walk(f, p, s)
+			if err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < val.Len(); i++ {
+			err := v.walk(val.Index(i), fmt.Sprintf("%s[%d]", path, i), secret)
+			if err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		iter := val.MapRange()
+		for iter.Next() {
+			err := v.walk(iter.Value(), fmt.Sprintf("%s[%v]", path, iter.Key()), secret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (v *validator) validator(val reflect.Value, path string) {
+	var obj any
+
+	if val.CanAddr() {
+		obj = val.Addr().Interface()
+	} else {
+		obj = val.Interface()
+	}
+
+	if c, ok := obj.(Validator); ok {
+		if err := c.Validate(); err != nil {
+			e := v.fieldError(path, "validate", err.Error())
+			e.Err = err
+			v.errors = append(v.errors, e)
+		}
+	}
+}
+
+func (v *validator) check(val reflect.Value, path string, tag string, secret bool) error {
+	rules, err := parse(tag)
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, path)
+	}
+
+	for _, r := range rules {
+		msg, err := apply(r, val, secret)
+		if err != nil {
+			return fmt.Errorf("%w: %s", err, path)
+		}
+
+		if msg != "" {
+			v.errors = append(v.errors, v.fieldError(path, r.name, msg))
+		}
+	}
+
+	return nil
+}
+
+func (v *validator) fieldError(path string, rule string, msg string) *FieldError {
+	e := &FieldError{
+		Path:    path,
+		Rule:    rule,
+		Message: msg,
+	}
+
+	if key, ok := v.o.Index.Key(path); ok {
+		e.Env = v.o.Prefix + key
+	}
+
+	if v.o.Flags != nil {
+		if f, ok := v.o.Flags.Flags()[path]; ok {
+			e.Flag = f.Name()
+		}
+	}
+
+	return e
+}
+
+func parse(tag string) ([]rule, error) {
+	var rules []rule
+
+	for _, txt := range lookup.Split(tag, ',') {
+		txt = strings.TrimSpace(txt)
+		if txt == "" {
+			continue
+		}
+
+		name, param, _ := strings.Cut(txt, "=")
+		param = strings.Trim(param, "'")
+
+		switch name {
+		case "required", "url", "hostport", "file_exists":
+		case "min", "max", "oneof", "pattern":
+			if param == "" {
+				return nil, fmt.Errorf("missing parameter for validation rule '%s'", name)
+			}
+		default:
+			return nil, fmt.Errorf("invalid validation rule: '%s'", name)
+		}
+
+		rules = append(rules, rule{name: name, param: param})
+	}
+
+	return rules, nil
+}
+
+// apply returns a message if the value violates the rule.
+func apply(r rule, val reflect.Value, secret bool) (string, error) {
+	if r.name == "required" {
+		if isEmpty(val) {
+			return "is required", nil
+		}
+
+		return "", nil
+	}
+
+	for val.Kind() == reflect.Pointer {
+		if val.IsNil() {
+			return "", nil
+		}
+
+		val = val.Elem()
+	}
+
+	switch r.name {
+	case "min", "max":
+		return limit(r, val, secret)
+	}
+
+	// all other rules only check non empty values
+	if isEmpty(val) {
+		return "", nil
+	}
+
+	txt := fmt.Sprint(val.Interface())
+
+	got := txt
+	if secret {
+		got = index.Redacted
+	}
+
+	switch r.name {
+	case "oneof":
+		values := strings.Fields(r.param)
+		if !slices.Contains(values, txt) {
+			return fmt.Sprintf("must be one of [%s], got '%s'", strings.Join(values, " "), got), nil
+		}
+	case "pattern":
+		re, err := regexp.Compile(r.param)
+		if err != nil {
+			return "", fmt.Errorf("invalid pattern '%s': %w", r.param, err)
+		}
+
+		if !re.MatchString(txt) {
+			return fmt.Sprintf("must match '%s', got '%s'", r.param, got), nil
+		}
+	case "url":
+		u, err := url.Parse(txt)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Sprintf("must be a valid URL, got '%s'", got), nil
+		}
+	case "hostport":
+		_, port, err := net.SplitHostPort(txt)
+		if err == nil {
+			_, err = strconv.ParseUint(port, 10, 16)
+		}
+
+		if err != nil {
+			return fmt.Sprintf("must be a host:port, got '%s'", got), nil
+		}
+	case "file_exists":
+		if _, err := os.Stat(txt); err != nil {
+			return fmt.Sprintf("file doesn't exist: '%s'", got), nil
+		}
+	}
+
+	return "", nil
+}
+
+// limit checks min and max of numbers, durations and the length of strings,
+// slices and maps.
+func limit(r rule, val reflect.Value, secret bool) (string, error) {
+	var actual, bound float64
+
+	switch {
+	case val.Type() == durationType:
+		d, err := time.ParseDuration(r.param)
+		if err != nil {
+			return "", fmt.Errorf("invalid %s duration '%s': %w", r.name, r.param, err)
+		}
+
+		actual = float64(val.Int())
+		bound = float64(d)
+	default:
+		b, err := strconv.ParseFloat(r.param, 64)
+		if err != nil {
+			return "", fmt.Errorf("invalid %s value '%s': %w", r.name, r.param, err)
+		}
+
+		bound = b
+
+		switch val.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			actual = float64(val.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			actual = float64(val.Uint())
+		case reflect.Float32, reflect.Float64:
+			actual = val.Float()
+		case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+			if r.name == "min" && val.Len() < int(bound) {
+				return fmt.Sprintf("length must be at least %s", r.param), nil
+			} else if r.name == "max" && val.Len() > int(bound) {
+				return fmt.Sprintf("length must be at most %s", r.param), nil
+			}
+
+			return "", nil
+		default:
+			return "", fmt.Errorf("validation rule '%s' not supported for %v", r.name, val.Type())
+		}
+	}
+
+	got := val.Interface()
+	if secret {
+		got = index.Redacted
+	}
+
+	if r.name == "min" && actual < bound {
+		return fmt.Sprintf("must be at least %s, got %v", r.param, got), nil
+	} else if r.name == "max" && actual > bound {
+		return fmt.Sprintf("must be at most %s, got %v", r.param, got), nil
+	}
+
+	return "", nil
+}
+
+func isEmpty(val reflect.Value) bool {
+	switch val.Kind() {
+	case reflect.Slice, reflect.Map:
+		return val.Len() == 0
+	default:
+		return val.IsZero()
+	}
+}
+
+func join(path string, name string) string {
+	if path == "" {
+		return name
+	}
+
+	return path + "." + name
+}
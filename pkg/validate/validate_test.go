@@ -0,0 +1,215 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package validate_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zauberhaus/config/pkg/flags"
+	"github.com/zauberhaus/config/pkg/validate"
+)
+
+type ValidateTestConfig struct {
+	Host    string        `check:"required"`
+	Port    int           `check:"min=1,max=65535"`
+	Level   string        `check:"oneof=debug info warn"`
+	Name    string        `check:"pattern='^[a-z]{2,8}$'"`
+	URL     string        `check:"url"`
+	Addr    string        `check:"hostport"`
+	File    string        `check:"file_exists"`
+	Timeout time.Duration `check:"min=1s"`
+	Tags    []string      `check:"required,max=2"`
+	Ratio   *float64      `check:"max=1"`
+	Servers []struct {
+		Host string `check:"required"`
+	}
+	Sub ValidateTestSub
+}
+
+type ValidateTestSub struct {
+	Name string `env:"LABEL" check:"required"`
+}
+
+func validConfig(t *testing.T) *ValidateTestConfig {
+	file := filepath.Join(t.TempDir(), "cert.pem")
+	require.NoError(t, os.WriteFile(file, nil, 0644))
+
+	return &ValidateTestConfig{
+		Host:    "localhost",
+		Port:    8080,
+		Level:   "info",
+		Name:    "app",
+		URL:     "https://example.com/path",
+		Addr:    "localhost:9000",
+		File:    file,
+		Timeout: time.Second,
+		Tags:    []string{"a"},
+		Sub:     ValidateTestSub{Name: "sub"},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		assert.NoError(t, validate.Validate(validConfig(t)))
+	})
+
+	t.Run("empty optional values", func(t *testing.T) {
+		cfg := validConfig(t)
+		cfg.Level = ""
+		cfg.Name = ""
+		cfg.URL = ""
+		cfg.Addr = ""
+		cfg.File = ""
+
+		assert.NoError(t, validate.Validate(cfg))
+	})
+
+	t.Run("violations", func(t *testing.T) {
+		ratio := 1.5
+
+		cfg := validConfig(t)
+		cfg.Host = ""
+		cfg.Port = 70000
+		cfg.Level = "trace"
+		cfg.Name = "App"
+		cfg.URL = "example.com"
+		cfg.Addr = "localhost"
+		cfg.File = "/not/existing"
+		cfg.Timeout = time.Millisecond
+		cfg.Tags = []string{"a", "b", "c"}
+		cfg.Ratio = &ratio
+		cfg.Servers = append(cfg.Servers, struct {
+			Host string `check:"required"`
+		}{})
+		cfg.Sub.Name = ""
+
+		err := validate.Validate(cfg, validate.WithName("app"))
+
+		var errs validate.Errors
+		require.True(t, errors.As(err, &errs))
+
+		var paths []string
+		for _, e := range errs {
+			paths = append(paths, e.Path)
+		}
+
+		assert.Equal(t, []string{"host", "port", "level", "name", "url", "addr", "file", "timeout", "tags", "ratio", "servers[0].host", "sub.name"}, paths)
+		assert.Equal(t, "APP_PORT", errs[1].Env)
+		assert.Equal(t, "APP_SERVERS[0]_HOST", errs[10].Env)
+		assert.Equal(t, "APP_SUB_LABEL", errs[11].Env)
+		assert.Equal(t, "min", errs[7].Rule)
+
+		assert.ErrorContains(t, err, "host (APP_HOST): is required")
+		assert.ErrorContains(t, err, "port (APP_PORT): must be at most 65535, got 70000")
+		assert.ErrorContains(t, err, "level (APP_LEVEL): must be one of [debug info warn], got 'trace'")
+		assert.ErrorContains(t, err, "tags (APP_TAGS): length must be at most 2")
+	})
+
+	t.Run("required slice", func(t *testing.T) {
+		cfg := validConfig(t)
+		cfg.Tags = []string{}
+
+		err := validate.Validate(cfg)
+		assert.EqualError(t, err, "validation failed:\n  tags (TAGS): is required")
+	})
+
+	t.Run("flag name", func(t *testing.T) {
+		flagSet := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		flagSet.Int("port", 0, "port")
+
+		fl := flags.NewFlagList(nil)
+		require.NoError(t, fl.BindFlag(flagSet, "Port", flagSet.Lookup("port")))
+
+		cfg := validConfig(t)
+		cfg.Port = 0
+
+		err := validate.Validate(cfg, validate.WithName("app"), validate.WithFlags(fl))
+		assert.EqualError(t, err, "validation failed:\n  port (APP_PORT, --port): must be at least 1, got 0")
+	})
+}
+
+func TestValidate_InvalidTag(t *testing.T) {
+	type UnknownRule struct {
+		Host string `check:"unknown"`
+	}
+
+	err := validate.Validate(&UnknownRule{})
+	assert.EqualError(t, err, "invalid validation rule: 'unknown': host")
+
+	type MissingParam struct {
+		Port int `check:"min"`
+	}
+
+	err = validate.Validate(&MissingParam{})
+	assert.EqualError(t, err, "missing parameter for validation rule 'min': port")
+
+	type InvalidMin struct {
+		Port int `check:"min=abc"`
+	}
+
+	err = validate.Validate(&InvalidMin{})
+	assert.ErrorContains(t, err, "invalid min value 'abc'")
+
+	type InvalidPattern struct {
+		Name string `check:"pattern=["`
+	}
+
+	err = validate.Validate(&InvalidPattern{Name: "abc"})
+	assert.ErrorContains(t, err, "invalid pattern '['")
+}
+
+var errNoHosts = errors.New("no hosts")
+
+type ValidatorTestConfig struct {
+	Cluster ValidatorTestCluster
+}
+
+type ValidatorTestCluster struct {
+	Hosts []string
+}
+
+func (c ValidatorTestCluster) Validate() error {
+	if len(c.Hosts) == 0 {
+		return errNoHosts
+	}
+
+	return nil
+}
+
+func TestValidate_Validator(t *testing.T) {
+	err := validate.Validate(&ValidatorTestConfig{}, validate.WithName("app"))
+	assert.EqualError(t, err, "validation failed:\n  cluster (APP_CLUSTER): no hosts")
+	assert.ErrorIs(t, err, errNoHosts)
+
+	err = validate.Validate(&ValidatorTestConfig{Cluster: ValidatorTestCluster{Hosts: []string{"a"}}})
+	assert.NoError(t, err)
+}
+
+func TestValidate_Secret(t *testing.T) {
+	type Config struct {
+		Password string `secret:"true" check:"pattern='^[a-z]+$'"`
+		Database struct {
+			Pin int `check:"min=1000"`
+		} `secret:"true"`
+	}
+
+	cfg := &Config{Password: "S3CR3T"}
+	cfg.Database.Pin = 42
+
+	err := validate.Validate(cfg)
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "S3CR3T")
+	assert.NotContains(t, err.Error(), "42")
+	assert.Contains(t, err.Error(), "password (PASSWORD): must match '^[a-z]+$', got '******'")
+	assert.Contains(t, err.Error(), "database.pin (DATABASE_PIN): must be at least 1000, got ******")
+}
@@ -0,0 +1,70 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package config
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Stage is a source of config values used to define the precedence. The
+// stage of a custom source is its name.
+type Stage string
+
+const (
+	DefaultsStage Stage = "defaults"
+	FileStage     Stage = "file"
+	EnvStage      Stage = "env"
+	FlagsStage    Stage = "flags"
+)
+
+// DefaultPrecedence is the order the sources are applied by default, from
+// lowest to highest precedence. Custom sources are applied after the files
+// in the order they were added.
+var DefaultPrecedence = []Stage{DefaultsStage, FileStage, EnvStage, FlagsStage}
+
+// precedence returns the stages in the order they are applied.
+func precedence(o *ConfigOptions) ([]Stage, error) {
+	sources := make([]Stage, 0, len(o.Sources))
+
+	for _, src := range o.Sources {
+		s := Stage(src.Name())
+
+		if slices.Contains(DefaultPrecedence, s) {
+			return nil, fmt.Errorf("invalid source name: '%s' is a builtin stage", s)
+		}
+
+		if slices.Contains(sources, s) {
+			return nil, fmt.Errorf("invalid source name: duplicate source '%s'", s)
+		}
+
+		sources = append(sources, s)
+	}
+
+	if len(o.Precedence) == 0 {
+		return slices.Insert(slices.Clone(DefaultPrecedence), 2, sources...), nil
+	}
+
+	for i, s := range o.Precedence {
+		switch s {
+		case DefaultsStage:
+			if i != 0 {
+				return nil, fmt.Errorf("invalid precedence: %s must be the first stage", s)
+			}
+		case FileStage, EnvStage, FlagsStage:
+		default:
+			if !slices.Contains(sources, s) {
+				return nil, fmt.Errorf("invalid precedence: unknown stage '%s'", s)
+			}
+		}
+
+		if slices.Index(o.Precedence, s) != i {
+			return nil, fmt.Errorf("invalid precedence: duplicate stage '%s'", s)
+		}
+	}
+
+	return o.Precedence, nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zauberhaus/config"
+	"github.com/zauberhaus/config/pkg/flags"
+)
+
+type PrecedenceConfig struct {
+	Host  string `default:"default.host"`
+	Port  int    `default:"1000"`
+	Name  string `default:"default-name"`
+	Level string `default:"info"`
+}
+
+func TestLoad_Precedence(t *testing.T) {
+	require.NoError(t, os.Chdir(t.TempDir()))
+
+	file := filepath.Join(t.TempDir(), "precedence.yaml")
+	require.NoError(t, os.WriteFile(file, []byte("host: file.host\nport: 2000\nname: file-name\n"), 0644))
+
+	t.Setenv("PRECEDENCE_HOST", "env.host")
+	t.Setenv("PRECEDENCE_PORT", "3000")
+
+	flagSet := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flagSet.String("host", "", "host")
+	require.NoError(t, flagSet.Set("host", "flag.host"))
+
+	fl := flags.NewFlagList(nil)
+	require.NoError(t, fl.BindFlag(flagSet, "Host", flagSet.Lookup("host")))
+
+	options := []config.Option{config.WithFile(file), config.WithName("precedence"), config.WithFlags(fl)}
+
+	t.Run("default order", func(t *testing.T) {
+		assert.Equal(t, []config.Stage{config.DefaultsStage, config.FileStage, config.EnvStage, config.FlagsStage}, config.DefaultPrecedence)
+
+		cfg, _, err := config.Load[*PrecedenceConfig](options...)
+		require.NoError(t, err)
+		assert.Equal(t, "flag.host", cfg.Host)
+		assert.Equal(t, 3000, cfg.Port)
+		assert.Equal(t, "file-name", cfg.Name)
+		assert.Equal(t, "info", cfg.Level)
+	})
+
+	t.Run("file over env", func(t *testing.T) {
+		cfg, _, err := config.Load[*PrecedenceConfig](append(options,
+			config.WithPrecedence(config.DefaultsStage, config.EnvStage, config.FileStage, config.FlagsStage))...)
+		require.NoError(t, err)
+		assert.Equal(t, "flag.host", cfg.Host)
+		assert.Equal(t, 2000, cfg.Port)
+		assert.Equal(t, "file-name", cfg.Name)
+	})
+
+	t.Run("env over flags", func(t *testing.T) {
+		cfg, _, err := config.Load[*PrecedenceConfig](append(options,
+			config.WithPrecedence(config.FileStage, config.FlagsStage, config.EnvStage))...)
+		require.NoError(t, err)
+		assert.Equal(t, "env.host", cfg.Host)
+		assert.Equal(t, 3000, cfg.Port)
+	})
+
+	t.Run("skip stages", func(t *testing.T) {
+		cfg, f, err := config.Load[*PrecedenceConfig](append(options,
+			config.WithPrecedence(config.EnvStage))...)
+		require.NoError(t, err)
+		assert.Equal(t, file, f)
+		assert.Equal(t, "env.host", cfg.Host)
+		assert.Equal(t, 3000, cfg.Port)
+		assert.Equal(t, "default-name", cfg.Name)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, _, err := config.Load[*PrecedenceConfig](append(options,
+			config.WithPrecedence(config.FileStage, config.DefaultsStage))...)
+		assert.EqualError(t, err, "invalid precedence: defaults must be the first stage")
+
+		_, _, err = config.Load[*PrecedenceConfig](append(options,
+			config.WithPrecedence(config.FileStage, config.FileStage))...)
+		assert.EqualError(t, err, "invalid precedence: duplicate stage 'file'")
+
+		_, _, err = config.Load[*PrecedenceConfig](append(options,
+			config.WithPrecedence("unknown"))...)
+		assert.EqualError(t, err, "invalid precedence: unknown stage 'unknown'")
+	})
+}
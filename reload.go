@@ -0,0 +1,61 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/zauberhaus/config/pkg/index"
+)
+
+// RestartRequiredError lists the changed fields with the tag
+// `reload:"restart"`.
+type RestartRequiredError struct {
+	Paths []string
+}
+
+func (e *RestartRequiredError) Error() string {
+	return fmt.Sprintf("restart required to change: %s", strings.Join(e.Paths, ", "))
+}
+
+// CheckReload returns a RestartRequiredError if fields with the tag
+// `reload:"restart"` differ between the old and the new config.
+func CheckReload[T any](old *T, new *T) error {
+	idx, err := index.New[T](nil)
+	if err != nil {
+		return err
+	}
+
+	var paths []string
+
+	for _, item := range idx.Items() {
+		if !item.Restart || strings.Contains(item.Path, "[]") {
+			continue
+		}
+
+		v1, _, err := value(old, item.Path)
+		if err != nil {
+			return err
+		}
+
+		v2, _, err := value(new, item.Path)
+		if err != nil {
+			return err
+		}
+
+		if !reflect.DeepEqual(v1, v2) {
+			paths = append(paths, item.Path)
+		}
+	}
+
+	if len(paths) > 0 {
+		return &RestartRequiredError{Paths: paths}
+	}
+
+	return nil
+}
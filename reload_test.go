@@ -0,0 +1,79 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package config_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zauberhaus/config"
+)
+
+type ReloadConfig struct {
+	Listen string `reload:"restart"`
+	Level  string
+	Data   struct {
+		Dir string
+	} `reload:"restart"`
+	TLS *struct {
+		Cert string `reload:"restart"`
+	}
+}
+
+func TestCheckReload(t *testing.T) {
+	old := &ReloadConfig{Listen: ":8080", Level: "info"}
+	old.Data.Dir = "/data"
+
+	t.Run("unchanged", func(t *testing.T) {
+		new := *old
+		new.Level = "debug"
+
+		assert.NoError(t, config.CheckReload(old, &new))
+	})
+
+	t.Run("changed", func(t *testing.T) {
+		new := *old
+		new.Listen = ":9090"
+		new.Data.Dir = "/other"
+		new.TLS = &struct {
+			Cert string `reload:"restart"`
+		}{Cert: "cert.pem"}
+
+		err := config.CheckReload(old, &new)
+
+		var rerr *config.RestartRequiredError
+		require.True(t, errors.As(err, &rerr))
+		assert.Equal(t, []string{"data", "listen", "tls.cert"}, rerr.Paths)
+		assert.EqualError(t, err, "restart required to change: data, listen, tls.cert")
+	})
+}
+
+func TestHolder_CheckReload(t *testing.T) {
+	require.NoError(t, os.Chdir(t.TempDir()))
+
+	file := filepath.Join(t.TempDir(), "reload.yaml")
+	require.NoError(t, os.WriteFile(file, []byte("listen: :8080\nlevel: info\n"), 0644))
+
+	h, err := config.NewHolder[ReloadConfig](config.WithFile(file))
+	require.NoError(t, err)
+
+	h.Check(config.CheckReload[ReloadConfig])
+
+	require.NoError(t, os.WriteFile(file, []byte("listen: :9090\nlevel: debug\n"), 0644))
+
+	var rerr *config.RestartRequiredError
+	assert.ErrorAs(t, h.Reload(), &rerr)
+	assert.Equal(t, ":8080", h.Get().Listen)
+	assert.Equal(t, "info", h.Get().Level)
+
+	require.NoError(t, os.WriteFile(file, []byte("listen: :8080\nlevel: debug\n"), 0644))
+	require.NoError(t, h.Reload())
+	assert.Equal(t, "debug", h.Get().Level)
+}
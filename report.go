@@ -0,0 +1,420 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package config
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/zauberhaus/config/pkg/index"
+	"github.com/zauberhaus/config/pkg/merge"
+	"github.com/zauberhaus/lookup"
+	"go.yaml.in/yaml/v3"
+)
+
+type OriginKind int
+
+const (
+	UnknownOrigin OriginKind = iota
+	DefaultOrigin
+	FileOrigin
+	EnvOrigin
+	FlagOrigin
+	SourceOrigin
+)
+
+func (k OriginKind) String() string {
+	switch k {
+	case DefaultOrigin:
+		return "default"
+	case FileOrigin:
+		return "file"
+	case EnvOrigin:
+		return "env"
+	case FlagOrigin:
+		return "flag"
+	case SourceOrigin:
+		return "source"
+	default:
+		return "unknown"
+	}
+}
+
+// Origin describes a source which set the value of a field. Name is the
+// file, env var, flag or custom source name and Line the line in a YAML or
+// JSON file.
+type Origin struct {
+	Kind  OriginKind
+	Name  string
+	Line  int
+	Value any
+}
+
+func (o Origin) String() string {
+	switch {
+	case o.Kind == FlagOrigin:
+		return "flag --" + o.Name
+	case o.Line > 0:
+		return fmt.Sprintf("%v %s:%d", o.Kind, o.Name, o.Line)
+	case o.Name != "":
+		return fmt.Sprintf("%v %s", o.Kind, o.Name)
+	default:
+		return o.Kind.String()
+	}
+}
+
+// Field contains the winning source of a field and the sources it overrode,
+// ordered from lowest to highest precedence.
+type Field struct {
+	Path       string
+	Origin     Origin
+	Overridden []Origin
+}
+
+// Report maps the paths of the config fields to the sources which set them.
+// Fields which no source set are reported as defaults with their zero
+// value, fields below a nil pointer are left out. Fields set by an env var
+// or flag for a single element like hosts[0] are reported with the element
+// path.
+type Report map[string]*Field
+
+// LoadWithReport works like Load, but returns a report which source set
+// each field.
+func LoadWithReport[P ~*T, T any](options ...Option) (P, Report, error) {
+	r := Report{}
+
+	cfg, _, _, err := load[T](append(options, withReport(r))...)
+
+	return cfg, r, err
+}
+
+func withReport(r Report) Option {
+	return optionFunc(func(o *ConfigOptions) {
+		o.report = r
+	})
+}
+
+// Paths returns the reported paths in sorted order.
+func (r Report) Paths() []string {
+	return slices.Sorted(maps.Keys(r))
+}
+
+func (r Report) String() string {
+	var sb strings.Builder
+
+	for _, p := range r.Paths() {
+		f := r[p]
+
+		fmt.Fprintf(&sb, "%s = %v (%v)", p, f.Origin.Value, f.Origin)
+
+		for i := len(f.Overridden) - 1; i >= 0; i-- {
+			o := f.Overridden[i]
+			fmt.Fprintf(&sb, ", overrides %v (%v)", o.Value, o)
+		}
+
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+func (r Report) add(path string, o Origin) {
+	if r == nil {
+		return
+	}
+
+	f, ok := r[path]
+	if !ok {
+		r[path] = &Field{
+			Path:   path,
+			Origin: o,
+		}
+
+		return
+	}
+
+	f.Overridden = append(f.Overridden, f.Origin)
+	f.Origin = o
+}
+
+func (r Report) observer(kind OriginKind) func(name string, path string, value any) {
+	return func(name string, path string, value any) {
+		r.add(path, Origin{
+			Kind:  kind,
+			Name:  name,
+			Value: value,
+		})
+	}
+}
+
+// addDefaults reports the non-zero values of unreported fields as defaults.
+// With zero also the fields which are still zero are reported, so the
+// report covers all reachable fields.
+func (r Report) addDefaults(cfg any, idx index.Index, zero bool) error {
+	if r == nil {
+		return nil
+	}
+
+	for _, p := range leafPaths(idx) {
+		if r.reported(p) {
+			continue
+		}
+
+		val, ok, err := value(cfg, p)
+		if err != nil {
+			return err
+		}
+
+		// nil slices, maps and pointers inside of a set struct
+		if !ok && zero {
+			val, ok, err = zeroValue(cfg, p, idx)
+			if err != nil {
+				return err
+			}
+		}
+
+		if !ok || !zero && reflect.ValueOf(val).IsZero() {
+			continue
+		}
+
+		r.add(p, Origin{
+			Kind:  DefaultOrigin,
+			Value: val,
+		})
+	}
+
+	return nil
+}
+
+// reported returns true if the path or a single element of it like
+// hosts[0] is reported.
+func (r Report) reported(p string) bool {
+	if _, ok := r[p]; ok {
+		return true
+	}
+
+	for k := range r {
+		if strings.HasPrefix(k, p+"[") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// addFile reports the fields set by a file.
+func (r Report) addFile(cfg any, f configFile, idx index.Index) error {
+	if r == nil {
+		return nil
+	}
+
+	keys := fileKeys(f)
+
+	for _, p := range leafPaths(idx) {
+		line, ok := keys[normalize(p)]
+		if !ok {
+			continue
+		}
+
+		val, ok, err := value(cfg, p)
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			continue
+		}
+
+		// lines of rewritten files refer to the expanded document
+		if f.rewritten {
+			line = 0
+		}
+
+		r.add(p, Origin{
+			Kind:  FileOrigin,
+			Name:  f.Name,
+			Line:  line,
+			Value: val,
+		})
+	}
+
+	return nil
+}
+
+// snapshot returns the current values of the fields to detect the changes
+// of a custom source.
+func (r Report) snapshot(cfg any, idx index.Index) (map[string]any, error) {
+	if r == nil {
+		return nil, nil
+	}
+
+	m := map[string]any{}
+
+	for _, p := range leafPaths(idx) {
+		val, ok, err := value(cfg, p)
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			m[p] = merge.Clone(val)
+		}
+	}
+
+	return m, nil
+}
+
+// addChanges reports the fields changed since the snapshot.
+func (r Report) addChanges(cfg any, idx index.Index, snapshot map[string]any, name string) error {
+	if r == nil {
+		return nil
+	}
+
+	for _, p := range leafPaths(idx) {
+		val, ok, err := value(cfg, p)
+		if err != nil {
+			return err
+		}
+
+		if !ok || reflect.DeepEqual(val, snapshot[p]) {
+			continue
+		}
+
+		r.add(p, Origin{
+			Kind:  SourceOrigin,
+			Name:  name,
+			Value: val,
+		})
+	}
+
+	return nil
+}
+
+// redact masks the values of secret fields.
+func (r Report) redact(idx index.Index) {
+	for p, f := range r {
+		if item, ok := idx.LookupPath(p); !ok || !item.Secret {
+			continue
+		}
+
+		f.Origin.Value = redact(f.Origin.Value)
+
+		for i := range f.Overridden {
+			f.Overridden[i].Value = redact(f.Overridden[i].Value)
+		}
+	}
+}
+
+func value(cfg any, path string) (any, bool, error) {
+	ok, err := lookup.Exists(cfg, path)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+
+	val, err := lookup.Get(cfg, path)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return val, true, nil
+}
+
+// zeroValue returns the zero value of a field if its parent exists.
+func zeroValue(cfg any, path string, idx index.Index) (any, bool, error) {
+	item, ok := idx.LookupPath(path)
+	if !ok || item.Type == nil {
+		return nil, false, nil
+	}
+
+	if i := strings.LastIndex(path, "."); i > 0 {
+		_, ok, err := value(cfg, path[:i])
+		if err != nil || !ok {
+			return nil, false, err
+		}
+	}
+
+	return reflect.Zero(item.Type).Interface(), true, nil
+}
+
+// leafPaths returns the paths of the index without child fields.
+func leafPaths(idx index.Index) []string {
+	var paths []string
+
+	for _, v := range idx {
+		if !strings.Contains(v.Path, "[]") {
+			paths = append(paths, v.Path)
+		}
+	}
+
+	slices.Sort(paths)
+
+	var result []string
+
+	for i, p := range paths {
+		if i+1 < len(paths) && strings.HasPrefix(paths[i+1], p+".") {
+			continue
+		}
+
+		result = append(result, p)
+	}
+
+	return result
+}
+
+// fileKeys returns the normalized paths of all keys in a file with their
+// line numbers. The lines are only available for YAML and JSON files.
+func fileKeys(f configFile) map[string]int {
+	keys := map[string]int{}
+
+	switch f.FileType {
+	case YAML, JSON:
+		var node yaml.Node
+		if err := yaml.Unmarshal(f.Data, &node); err == nil {
+			nodeKeys(&node, "", keys)
+		}
+	default:
+		var m map[string]any
+		if err := decode(f.Name, f.FileType, f.Data, &m); err == nil {
+			mapKeys(m, "", keys)
+		}
+	}
+
+	return keys
+}
+
+func nodeKeys(node *yaml.Node, prefix string, keys map[string]int) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, c := range node.Content {
+			nodeKeys(c, prefix, keys)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			path := prefix + normalize(node.Content[i].Value)
+			keys[path] = node.Content[i].Line
+			nodeKeys(node.Content[i+1], path+".", keys)
+		}
+	}
+}
+
+func mapKeys(m map[string]any, prefix string, keys map[string]int) {
+	for k, v := range m {
+		path := prefix + normalize(k)
+		keys[path] = 0
+
+		if tmp, ok := v.(map[string]any); ok {
+			mapKeys(tmp, path+".", keys)
+		}
+	}
+}
+
+// normalize matches keys like max_conn or max-conn with the field MaxConn.
+func normalize(key string) string {
+	return strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(key))
+}
@@ -0,0 +1,111 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zauberhaus/config"
+	"github.com/zauberhaus/config/pkg/flags"
+)
+
+func TestLoadWithReport(t *testing.T) {
+	tempDir := t.TempDir()
+	require.NoError(t, os.Chdir(tempDir))
+
+	base := filepath.Join(tempDir, "base.yaml")
+	require.NoError(t, os.WriteFile(base, []byte("host: base.host.com\nport: 1000\nserver:\n  name: base\n"), 0644))
+
+	app := filepath.Join(tempDir, "app.json")
+	require.NoError(t, os.WriteFile(app, []byte("{\n  \"port\": 2000,\n  \"tls\": {\"cert\": \"tls.pem\"}\n}"), 0644))
+
+	t.Setenv("REPORT_PORT", "3000")
+	t.Setenv("REPORT_TAGS", "a=b")
+
+	flagSet := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flagSet.Int("port", 0, "port")
+	require.NoError(t, flagSet.Set("port", "4000"))
+
+	fl := flags.NewFlagList(nil)
+	require.NoError(t, fl.BindFlag(flagSet, "Port", flagSet.Lookup("port")))
+
+	cfg, r, err := config.LoadWithReport[*TestLayeredConfig](
+		config.WithFiles(base, app),
+		config.WithName("report"),
+		config.WithFlags(fl),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, 4000, cfg.Port)
+
+	t.Run("default", func(t *testing.T) {
+		f := r["server.timeout"]
+		require.NotNil(t, f)
+		assert.Equal(t, config.Origin{Kind: config.DefaultOrigin, Value: 30}, f.Origin)
+		assert.Empty(t, f.Overridden)
+	})
+
+	t.Run("file", func(t *testing.T) {
+		f := r["host"]
+		require.NotNil(t, f)
+		assert.Equal(t, config.Origin{Kind: config.FileOrigin, Name: base, Line: 1, Value: "base.host.com"}, f.Origin)
+		assert.Equal(t, []config.Origin{{Kind: config.DefaultOrigin, Value: "localhost"}}, f.Overridden)
+
+		f = r["tls.cert"]
+		require.NotNil(t, f)
+		assert.Equal(t, config.Origin{Kind: config.FileOrigin, Name: app, Line: 3, Value: "tls.pem"}, f.Origin)
+	})
+
+	t.Run("default of created struct", func(t *testing.T) {
+		f := r["tls.key"]
+		require.NotNil(t, f)
+		assert.Equal(t, config.DefaultOrigin, f.Origin.Kind)
+		assert.Equal(t, "key.pem", f.Origin.Value)
+	})
+
+	t.Run("env", func(t *testing.T) {
+		f := r["tags"]
+		require.NotNil(t, f)
+		assert.Equal(t, config.Origin{Kind: config.EnvOrigin, Name: "REPORT_TAGS", Value: map[string]string{"a": "b"}}, f.Origin)
+	})
+
+	t.Run("flag", func(t *testing.T) {
+		f := r["port"]
+		require.NotNil(t, f)
+		assert.Equal(t, config.Origin{Kind: config.FlagOrigin, Name: "port", Value: 4000}, f.Origin)
+		assert.Equal(t, []config.Origin{
+			{Kind: config.DefaultOrigin, Value: 8080},
+			{Kind: config.FileOrigin, Name: base, Line: 2, Value: 1000},
+			{Kind: config.FileOrigin, Name: app, Line: 2, Value: 2000},
+			{Kind: config.EnvOrigin, Name: "REPORT_PORT", Value: 3000},
+		}, f.Overridden)
+	})
+
+	t.Run("unset", func(t *testing.T) {
+		f := r["hosts"]
+		require.NotNil(t, f)
+		assert.Equal(t, config.Origin{Kind: config.DefaultOrigin, Value: []string(nil)}, f.Origin)
+		assert.Equal(t, []string{"host", "hosts", "port", "server.name", "server.timeout", "tags", "tls.cert", "tls.key"}, r.Paths())
+
+		_, r, err := config.LoadWithReport[*TestLayeredConfig]()
+		require.NoError(t, err)
+
+		f = r["server.name"]
+		require.NotNil(t, f)
+		assert.Equal(t, config.Origin{Kind: config.DefaultOrigin, Value: ""}, f.Origin)
+		assert.NotContains(t, r, "tls.cert")
+	})
+
+	t.Run("string", func(t *testing.T) {
+		txt := r.String()
+		assert.Contains(t, txt, "port = 4000 (flag --port), overrides 3000 (env REPORT_PORT), overrides 2000 (file "+app+":2)")
+		assert.Contains(t, txt, "server.timeout = 30 (default)\n")
+	})
+}
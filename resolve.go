@@ -0,0 +1,245 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package config
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"os/exec"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/zauberhaus/config/pkg/index"
+)
+
+// SecretResolver returns the value of a secret reference. The ref is passed
+// without the prefix of the scheme like env: or file://.
+type SecretResolver interface {
+	Resolve(ctx context.Context, ref string) (string, error)
+}
+
+// SecretResolverFunc is a function which implements SecretResolver.
+type SecretResolverFunc func(ctx context.Context, ref string) (string, error)
+
+func (f SecretResolverFunc) Resolve(ctx context.Context, ref string) (string, error) {
+	return f(ctx, ref)
+}
+
+// secretResolvers are the builtin schemes besides file:///path, which
+// depends on the options: env:NAME reads an env var.
+var secretResolvers = map[string]SecretResolver{
+	"env:": SecretResolverFunc(resolveEnv),
+}
+
+// ExecResolver returns the output of a command like "pass show db", run
+// without a shell. It isn't enabled by default, since everyone who can
+// write a config source could run commands:
+//
+//	config.WithSecretResolver("exec:", config.ExecResolver)
+var ExecResolver SecretResolver = SecretResolverFunc(resolveExec)
+
+// resolveFile reads a secret file confined to the roots and limited in size
+// like a config file. It's not signed and not part of the file system set
+// by WithFS, and mounted secrets are often readable by others.
+func resolveFile(o *ConfigOptions, ref string) (string, error) {
+	tmp := *o
+	tmp.FS = nil
+	tmp.PublicKeys = nil
+	tmp.PermissionPolicy = IgnorePermissions
+
+	data, err := readFile(&tmp, ref)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+func resolveEnv(ctx context.Context, ref string) (string, error) {
+	val, ok := os.LookupEnv(ref)
+	if !ok {
+		return "", fmt.Errorf("env var not set: %s", ref)
+	}
+
+	return val, nil
+}
+
+func resolveExec(ctx context.Context, ref string) (string, error) {
+	args := strings.Fields(ref)
+	if len(args) == 0 {
+		return "", fmt.Errorf("missing command")
+	}
+
+	out, err := exec.CommandContext(ctx, args[0], args[1:]...).Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return "", fmt.Errorf("%w: %s", err, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+
+		return "", err
+	}
+
+	return strings.TrimRight(string(out), "\r\n"), nil
+}
+
+type secretValue interface {
+	ref() reflect.Value
+}
+
+type resolver struct {
+	ctx      context.Context
+	all      bool
+	schemes  map[string]SecretResolver
+	prefixes []string
+}
+
+// resolveSecrets replaces the secret references in the secret fields, with
+// ResolveAllReferences in all string fields.
+func resolveSecrets(o *ConfigOptions, cfg any) error {
+	r := &resolver{
+		ctx: o.Context,
+		all: o.ResolveAllReferences,
+		schemes: map[string]SecretResolver{
+			"file://": SecretResolverFunc(func(ctx context.Context, ref string) (string, error) {
+				return resolveFile(o, ref)
+			}),
+		},
+	}
+
+	if r.ctx == nil {
+		r.ctx = context.Background()
+	}
+
+	for k, v := range secretResolvers {
+		r.schemes[k] = v
+	}
+
+	for k, v := range o.SecretResolvers {
+		if v == nil {
+			delete(r.schemes, k)
+		} else {
+			r.schemes[k] = v
+		}
+	}
+
+	if len(r.schemes) == 0 {
+		return nil
+	}
+
+	// longer prefixes first, so vault:// wins over vault:
+	r.prefixes = slices.SortedFunc(maps.Keys(r.schemes), func(a string, b string) int {
+		return cmp.Or(len(b)-len(a), strings.Compare(a, b))
+	})
+
+	return r.walk(reflect.ValueOf(cfg), "", false)
+}
+
+func (r *resolver) walk(val reflect.Value, path string, secret bool) error {
+	switch val.Kind() {
+	case reflect.Pointer:
+		if val.IsNil() {
+			return nil
+		}
+
+		return r.walk(val.Elem(), path, secret)
+	case reflect.Interface:
+		if val.IsNil() {
+			return nil
+		}
+
+		// values in interfaces aren't settable
+		tmp := reflect.New(val.Elem().Type()).Elem()
+		tmp.Set(val.Elem())
+
+		err := r.walk(tmp, path, secret)
+		if err != nil {
+			return err
+		}
+
+		if val.CanSet() {
+			val.Set(tmp)
+		}
+	case reflect.String:
+		if !val.CanSet() || !secret && !r.all {
+			return nil
+		}
+
+		txt, err := r.resolve(val.String())
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+
+		val.SetString(txt)
+	case reflect.Struct:
+		if val.CanAddr() {
+			if s, ok := val.Addr().Interface().(secretValue); ok {
+				return r.walk(s.ref(), path, true)
+			}
+		}
+
+		t := val.Type()
+
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+
+			err := r.walk(val.Field(i), join(path, strings.ToLower(field.Name)), secret || index.IsSecret(field))
+			if err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < val.Len(); i++ {
+			err := r.walk(val.Index(i), fmt.Sprintf("%s[%d]", path, i), secret)
+			if err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		// map values aren't settable, they are resolved in a copy
+		for _, k := range val.MapKeys() {
+			tmp := reflect.New(val.Type().Elem()).Elem()
+			tmp.Set(val.MapIndex(k))
+
+			err := r.walk(tmp, fmt.Sprintf("%s[%v]", path, k), secret)
+			if err != nil {
+				return err
+			}
+
+			val.SetMapIndex(k, tmp)
+		}
+	}
+
+	return nil
+}
+
+// resolve returns the value of a reference, other values are returned
+// unchanged.
+func (r *resolver) resolve(txt string) (string, error) {
+	for _, p := range r.prefixes {
+		ref, ok := strings.CutPrefix(txt, p)
+		if !ok {
+			continue
+		}
+
+		val, err := r.schemes[p].Resolve(r.ctx, ref)
+		if err != nil {
+			return "", fmt.Errorf("can't resolve %s reference: %w", strings.TrimRight(p, ":/"), err)
+		}
+
+		return val, nil
+	}
+
+	return txt, nil
+}
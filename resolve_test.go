@@ -0,0 +1,177 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package config_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zauberhaus/config"
+	"github.com/zauberhaus/config/pkg/flags"
+)
+
+type ResolveTestConfig struct {
+	Password string `secret:"true"`
+	Token    config.Secret[string]
+	Key      string `secret:"true"`
+	URL      string
+	Storage  string
+	Users    []string
+	Labels   map[string]string
+	Database *struct {
+		Password string `secret:"true"`
+	}
+}
+
+func TestLoad_SecretReferences(t *testing.T) {
+	tempDir := t.TempDir()
+	require.NoError(t, os.Chdir(tempDir))
+
+	secret := filepath.Join(tempDir, "db")
+	require.NoError(t, os.WriteFile(secret, []byte("s3cr3t\n"), 0600))
+
+	file := filepath.Join(tempDir, "config.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(`
+password: file://`+secret+`
+token: env:RESOLVE_TEST_TOKEN
+url: http://localhost
+storage: file:///var/lib/app
+users:
+  - env:RESOLVE_TEST_USER
+labels:
+  owner: env:RESOLVE_TEST_USER
+database:
+  password: file://`+secret+`
+`), 0644))
+
+	t.Setenv("RESOLVE_TEST_TOKEN", "t0k3n")
+	t.Setenv("RESOLVE_TEST_USER", "admin")
+	t.Setenv("RESOLVE_KEY", "env:RESOLVE_TEST_TOKEN")
+
+	cfg, _, err := config.Load[*ResolveTestConfig](config.WithFile(file), config.WithName("resolve"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "s3cr3t", cfg.Password)
+	assert.Equal(t, "t0k3n", cfg.Token.Value())
+	assert.Equal(t, "t0k3n", cfg.Key)
+	assert.Equal(t, "http://localhost", cfg.URL)
+	require.NotNil(t, cfg.Database)
+	assert.Equal(t, "s3cr3t", cfg.Database.Password)
+
+	// references in other fields are kept
+	assert.Equal(t, "file:///var/lib/app", cfg.Storage)
+	assert.Equal(t, []string{"env:RESOLVE_TEST_USER"}, cfg.Users)
+	assert.Equal(t, map[string]string{"owner": "env:RESOLVE_TEST_USER"}, cfg.Labels)
+
+	t.Run("all", func(t *testing.T) {
+		cfg, _, err := config.Load[*ResolveTestConfig](config.WithData([]byte(`
+users:
+  - env:RESOLVE_TEST_USER
+labels:
+  owner: env:RESOLVE_TEST_USER
+`), config.YAML), config.ResolveAllReferences)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"admin"}, cfg.Users)
+		assert.Equal(t, map[string]string{"owner": "admin"}, cfg.Labels)
+	})
+
+	t.Run("flag", func(t *testing.T) {
+		flagSet := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		flagSet.String("password", "", "password")
+		require.NoError(t, flagSet.Set("password", "env:RESOLVE_TEST_USER"))
+
+		fl := flags.NewFlagList(nil)
+		require.NoError(t, fl.BindFlag(flagSet, "Password", flagSet.Lookup("password")))
+
+		cfg, _, err := config.Load[*ResolveTestConfig](config.WithFile(file), config.WithFlags(fl))
+		require.NoError(t, err)
+		assert.Equal(t, "admin", cfg.Password)
+	})
+
+	t.Run("exec", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("no echo command")
+		}
+
+		t.Setenv("RESOLVE_PASSWORD", "exec:echo -n hello")
+
+		// commands are only run if enabled
+		cfg, _, err := config.Load[*ResolveTestConfig](config.WithName("resolve"))
+		require.NoError(t, err)
+		assert.Equal(t, "exec:echo -n hello", cfg.Password)
+
+		cfg, _, err = config.Load[*ResolveTestConfig](config.WithName("resolve"), config.WithSecretResolver("exec:", config.ExecResolver))
+		require.NoError(t, err)
+		assert.Equal(t, "hello", cfg.Password)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		t.Setenv("RESOLVE_DATABASE_PASSWORD", "env:RESOLVE_TEST_MISSING")
+
+		_, _, err := config.Load[*ResolveTestConfig](config.WithName("resolve"))
+		assert.EqualError(t, err, "database.password: can't resolve env reference: env var not set: RESOLVE_TEST_MISSING")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		t.Setenv("RESOLVE_USERS", "file:///not/existing")
+
+		_, _, err := config.Load[*ResolveTestConfig](config.WithName("resolve"), config.ResolveAllReferences)
+		assert.ErrorContains(t, err, "users[0]: can't resolve file reference:")
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("file limits", func(t *testing.T) {
+		t.Setenv("RESOLVE_PASSWORD", "file://"+secret)
+
+		_, _, err := config.Load[*ResolveTestConfig](config.WithName("resolve"), config.WithMaxFileSize(3))
+		assert.EqualError(t, err, "password: can't resolve file reference: file too large: '"+secret+"' (max 3 bytes)")
+
+		_, _, err = config.Load[*ResolveTestConfig](config.WithName("resolve"), config.WithRoot(t.TempDir()))
+
+		var te *config.TraversalError
+		assert.True(t, errors.As(err, &te))
+	})
+}
+
+func TestWithSecretResolver(t *testing.T) {
+	require.NoError(t, os.Chdir(t.TempDir()))
+
+	t.Setenv("CUSTOM_PASSWORD", "vault://db/password")
+	t.Setenv("CUSTOM_KEY", "env:CUSTOM_TEST_KEY")
+	t.Setenv("CUSTOM_TEST_KEY", "k3y")
+
+	vault := config.SecretResolverFunc(func(ctx context.Context, ref string) (string, error) {
+		if ref == "db/password" {
+			return "v4ult", nil
+		}
+
+		return "", errors.New("not found")
+	})
+
+	cfg, _, err := config.Load[*ResolveTestConfig](
+		config.WithName("custom"),
+		config.WithSecretResolver("vault://", vault),
+		config.WithSecretResolver("env:", nil),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "v4ult", cfg.Password)
+	assert.Equal(t, "env:CUSTOM_TEST_KEY", cfg.Key)
+
+	t.Run("error", func(t *testing.T) {
+		t.Setenv("CUSTOM_TOKEN", "vault://other")
+
+		_, _, err := config.Load[*ResolveTestConfig](config.WithName("custom"), config.WithSecretResolver("vault://", vault))
+		assert.EqualError(t, err, "token: can't resolve vault reference: not found")
+	})
+}
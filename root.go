@@ -0,0 +1,235 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// DefaultMaxFileSize limits the size of config files.
+const DefaultMaxFileSize = 10 << 20
+
+type SymlinkPolicy int
+
+const (
+	// FollowSymlinks follows symlinks, with WithRoot only if the target is
+	// inside of an allowed directory.
+	FollowSymlinks SymlinkPolicy = iota
+	// DenySymlinks rejects config files which are symlinks.
+	DenySymlinks
+)
+
+// TraversalError is returned for a path outside of the allowed directories
+// or, without WithRoot, for a path with a .. element.
+type TraversalError struct {
+	Path string
+}
+
+func (e *TraversalError) Error() string {
+	return fmt.Sprintf("path traversal attempt: '%s'", e.Path)
+}
+
+// SymlinkError is returned for a symlink if symlinks are denied.
+type SymlinkError struct {
+	Path string
+}
+
+func (e *SymlinkError) Error() string {
+	return fmt.Sprintf("symlink not allowed: '%s'", e.Path)
+}
+
+// FileSizeError is returned for a file larger than the maximum size.
+type FileSizeError struct {
+	Path string
+	Max  int64
+}
+
+func (e *FileSizeError) Error() string {
+	return fmt.Sprintf("file too large: '%s' (max %d bytes)", e.Path, e.Max)
+}
+
+// checkPath returns the absolute path of a file or directory, or the clean
+// path for a file system set by WithFS. Without roots paths with ..
+// elements are rejected, otherwise the path must be inside of a root.
+// Symlinks are checked when the file is read.
+func checkPath(o *ConfigOptions, name string) (string, error) {
+	// a file system set by WithFS can't be left
+	if o.FS != nil {
+		clean := path.Clean(name)
+		if !fs.ValidPath(clean) {
+			return "", &TraversalError{Path: name}
+		}
+
+		return clean, nil
+	}
+
+	if len(o.Roots) == 0 && hasParent(name) {
+		return "", &TraversalError{Path: name}
+	}
+
+	abs, err := filepath.Abs(name)
+	if err != nil {
+		return "", fmt.Errorf("invalid path '%s': %w", name, err)
+	}
+
+	if len(o.Roots) > 0 {
+		if _, _, ok := findRoot(o, abs, false); !ok {
+			return "", &TraversalError{Path: name}
+		}
+	}
+
+	return abs, nil
+}
+
+// hasParent returns true if the path contains a .. element.
+func hasParent(name string) bool {
+	return slices.Contains(strings.Split(filepath.ToSlash(name), "/"), "..")
+}
+
+// findRoot returns the root which contains the path and the relative path.
+// With resolve the symlinks of the roots are resolved.
+func findRoot(o *ConfigOptions, abs string, resolve bool) (string, string, bool) {
+	for _, r := range o.Roots {
+		root, err := filepath.Abs(r)
+		if err != nil {
+			continue
+		}
+
+		if resolve {
+			if tmp, err := filepath.EvalSymlinks(root); err == nil {
+				root = tmp
+			}
+		}
+
+		rel, err := filepath.Rel(root, abs)
+		if err == nil && filepath.IsLocal(rel) {
+			return root, rel, true
+		}
+	}
+
+	return "", "", false
+}
+
+// readFile reads a config file, confined to the roots, the symlink policy,
+// the permission policy and the maximum file size. With public keys the
+// signature is verified.
+func readFile(o *ConfigOptions, name string) ([]byte, error) {
+	f, err := open(o, name)
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	limit := maxFileSize(o)
+
+	data, err := io.ReadAll(io.LimitReader(f, limit+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(data)) > limit {
+		return nil, &FileSizeError{Path: name, Max: limit}
+	}
+
+	if len(o.PublicKeys) > 0 {
+		err = verify(o, name, data)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return data, nil
+}
+
+// open opens a config file of the file system set by WithFS or a checked
+// file of the OS.
+func open(o *ConfigOptions, name string) (fs.File, error) {
+	if o.FS != nil {
+		return openFS(o, name)
+	}
+
+	abs, err := checkPath(o, name)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := openFile(o, abs)
+	if err != nil {
+		var pe *fs.PathError
+		if errors.As(err, &pe) {
+			pe.Path = name
+		}
+
+		return nil, err
+	}
+
+	fi, err := f.Stat()
+	if err == nil {
+		err = checkPermissions(o, abs, fi)
+	}
+
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return f, nil
+}
+
+func maxFileSize(o *ConfigOptions) int64 {
+	if o.MaxFileSize <= 0 {
+		return DefaultMaxFileSize
+	}
+
+	return o.MaxFileSize
+}
+
+func openFile(o *ConfigOptions, abs string) (*os.File, error) {
+	if o.SymlinkPolicy == DenySymlinks {
+		fi, err := os.Lstat(abs)
+		if err != nil {
+			return nil, err
+		}
+
+		if fi.Mode()&fs.ModeSymlink != 0 {
+			return nil, &SymlinkError{Path: abs}
+		}
+	}
+
+	if len(o.Roots) == 0 {
+		return os.Open(abs)
+	}
+
+	// the target of symlinks must be inside of a root too
+	target, err := filepath.EvalSymlinks(abs)
+	if err != nil {
+		return nil, err
+	}
+
+	dir, rel, ok := findRoot(o, target, true)
+	if !ok {
+		return nil, &TraversalError{Path: abs}
+	}
+
+	// os.Root rejects symlinks swapped in after the check
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	defer root.Close()
+
+	return root.Open(rel)
+}
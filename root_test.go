@@ -0,0 +1,168 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package config_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zauberhaus/config"
+)
+
+func symlink(t *testing.T, target string, name string) {
+	if err := os.Symlink(target, name); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+}
+
+func TestLoad_Root(t *testing.T) {
+	tempDir := t.TempDir()
+	require.NoError(t, os.Chdir(tempDir))
+
+	root := filepath.Join(tempDir, "etc")
+	outside := filepath.Join(tempDir, "outside")
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "conf.d"), 0755))
+	require.NoError(t, os.MkdirAll(outside, 0755))
+
+	file := filepath.Join(root, "conf.d", "app.yaml")
+	require.NoError(t, os.WriteFile(file, []byte("host: root.host.com\nserver: !include ../server.yaml\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "server.yaml"), []byte("name: server\n"), 0644))
+
+	secret := filepath.Join(outside, "secret.yaml")
+	require.NoError(t, os.WriteFile(secret, []byte("host: secret.host.com\n"), 0644))
+
+	t.Run("inside", func(t *testing.T) {
+		cfg, _, err := config.Load[*TestLayeredConfig](config.WithFile(file), config.WithRoot(root))
+		require.NoError(t, err)
+		assert.Equal(t, "root.host.com", cfg.Host)
+		assert.Equal(t, "server", cfg.Server.Name)
+	})
+
+	t.Run("outside", func(t *testing.T) {
+		_, _, err := config.Load[*TestLayeredConfig](config.WithFile(secret), config.WithRoot(root))
+
+		var te *config.TraversalError
+		require.True(t, errors.As(err, &te))
+		assert.Equal(t, secret, te.Path)
+	})
+
+	t.Run("relative", func(t *testing.T) {
+		_, _, err := config.Load[*TestLayeredConfig](config.WithFile("etc/../outside/secret.yaml"), config.WithRoot("etc"))
+
+		var te *config.TraversalError
+		assert.True(t, errors.As(err, &te))
+	})
+
+	t.Run("multiple roots", func(t *testing.T) {
+		cfg, _, err := config.Load[*TestLayeredConfig](config.WithFiles(file, secret), config.WithRoot(root, outside))
+		require.NoError(t, err)
+		assert.Equal(t, "secret.host.com", cfg.Host)
+	})
+
+	t.Run("env", func(t *testing.T) {
+		t.Setenv("CONFIG", secret)
+
+		_, _, err := config.Load[*TestLayeredConfig](config.WithRoot(root))
+
+		var te *config.TraversalError
+		assert.True(t, errors.As(err, &te))
+	})
+
+	t.Run("include", func(t *testing.T) {
+		main := filepath.Join(root, "main.yaml")
+		require.NoError(t, os.WriteFile(main, []byte("server: !include ../outside/secret.yaml\n"), 0644))
+
+		_, _, err := config.Load[*TestLayeredConfig](config.WithFile(main), config.WithRoot(root))
+
+		var te *config.TraversalError
+		assert.True(t, errors.As(err, &te))
+	})
+
+	t.Run("discovery", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(filepath.Join(outside, "app.yaml"), []byte("host: outside.host.com\n"), 0644))
+
+		cfg, _, err := config.Load[*TestLayeredConfig](config.WithName("app"), config.WithPaths(outside, filepath.Join(root, "conf.d")), config.WithRoot(root))
+		require.NoError(t, err)
+		assert.Equal(t, "root.host.com", cfg.Host)
+	})
+
+	t.Run("symlink inside", func(t *testing.T) {
+		link := filepath.Join(root, "conf.d", "link.yaml")
+		symlink(t, file, link)
+
+		cfg, _, err := config.Load[*TestLayeredConfig](config.WithFile(link), config.WithRoot(root))
+		require.NoError(t, err)
+		assert.Equal(t, "root.host.com", cfg.Host)
+
+		_, _, err = config.Load[*TestLayeredConfig](config.WithFile(link), config.WithRoot(root), config.WithSymlinkPolicy(config.DenySymlinks))
+
+		var se *config.SymlinkError
+		assert.True(t, errors.As(err, &se))
+	})
+
+	t.Run("symlink escape", func(t *testing.T) {
+		link := filepath.Join(root, "escape.yaml")
+		symlink(t, secret, link)
+
+		_, _, err := config.Load[*TestLayeredConfig](config.WithFile(link), config.WithRoot(root))
+
+		var te *config.TraversalError
+		assert.True(t, errors.As(err, &te))
+
+		// a directory in the root linked to another directory
+		dir := filepath.Join(root, "escape")
+		symlink(t, outside, dir)
+
+		_, _, err = config.Load[*TestLayeredConfig](config.WithFile(filepath.Join(dir, "secret.yaml")), config.WithRoot(root))
+		assert.True(t, errors.As(err, &te))
+	})
+
+	t.Run("not existing", func(t *testing.T) {
+		_, _, err := config.Load[*TestLayeredConfig](config.WithFile(filepath.Join(root, "missing.yaml")), config.WithRoot(root))
+		assert.True(t, os.IsNotExist(err))
+	})
+}
+
+func TestLoad_PathNames(t *testing.T) {
+	tempDir := t.TempDir()
+	require.NoError(t, os.Chdir(tempDir))
+
+	file := filepath.Join(tempDir, "app..yaml")
+	require.NoError(t, os.WriteFile(file, []byte("host: dots.host.com\n"), 0644))
+
+	cfg, _, err := config.Load[*TestLayeredConfig](config.WithFile(file))
+	require.NoError(t, err)
+	assert.Equal(t, "dots.host.com", cfg.Host)
+
+	_, _, err = config.Load[*TestLayeredConfig](config.WithFile("../app.yaml"))
+
+	var te *config.TraversalError
+	require.True(t, errors.As(err, &te))
+	assert.EqualError(t, err, "path traversal attempt: '../app.yaml'")
+}
+
+func TestLoad_MaxFileSize(t *testing.T) {
+	tempDir := t.TempDir()
+	require.NoError(t, os.Chdir(tempDir))
+
+	file := filepath.Join(tempDir, "config.yaml")
+	require.NoError(t, os.WriteFile(file, []byte("host: "+strings.Repeat("a", 100)+"\n"), 0644))
+
+	_, _, err := config.Load[*TestLayeredConfig](config.WithFile(file), config.WithMaxFileSize(64))
+
+	var fe *config.FileSizeError
+	require.True(t, errors.As(err, &fe))
+	assert.Equal(t, int64(64), fe.Max)
+	assert.EqualError(t, err, "file too large: '"+file+"' (max 64 bytes)")
+
+	_, _, err = config.Load[*TestLayeredConfig](config.WithFile(file), config.WithMaxFileSize(1024))
+	assert.NoError(t, err)
+}
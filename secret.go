@@ -0,0 +1,95 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"reflect"
+
+	"github.com/zauberhaus/config/pkg/index"
+	"github.com/zauberhaus/lookup"
+	"go.yaml.in/yaml/v3"
+)
+
+// Secret wraps a value which is redacted when it's printed, logged or
+// marshaled. The value can be read with Value.
+type Secret[T any] struct {
+	value T
+}
+
+// NewSecret returns a secret with the value.
+func NewSecret[T any](value T) Secret[T] {
+	return Secret[T]{value: value}
+}
+
+// Value returns the wrapped value.
+func (s Secret[T]) Value() T {
+	return s.value
+}
+
+// Secret returns the wrapped value and marks the type as secret.
+func (s Secret[T]) Secret() any {
+	return s.value
+}
+
+// ref returns the settable value to resolve secret references.
+func (s *Secret[T]) ref() reflect.Value {
+	return reflect.ValueOf(&s.value).Elem()
+}
+
+func (s Secret[T]) String() string {
+	return index.Redacted
+}
+
+func (s Secret[T]) GoString() string {
+	return index.Redacted
+}
+
+func (s Secret[T]) Format(f fmt.State, verb rune) {
+	_, _ = f.Write([]byte(index.Redacted))
+}
+
+func (s Secret[T]) LogValue() slog.Value {
+	return slog.StringValue(index.Redacted)
+}
+
+func (s Secret[T]) MarshalText() ([]byte, error) {
+	return []byte(index.Redacted), nil
+}
+
+func (s *Secret[T]) UnmarshalText(data []byte) error {
+	val, err := lookup.Parse(string(data), reflect.TypeFor[T]())
+	if err != nil {
+		return err
+	}
+
+	v, ok := val.(T)
+	if !ok {
+		return fmt.Errorf("invalid secret value: %T", val)
+	}
+
+	s.value = v
+
+	return nil
+}
+
+func (s Secret[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(index.Redacted)
+}
+
+func (s *Secret[T]) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &s.value)
+}
+
+func (s Secret[T]) MarshalYAML() (any, error) {
+	return index.Redacted, nil
+}
+
+func (s *Secret[T]) UnmarshalYAML(node *yaml.Node) error {
+	return node.Decode(&s.value)
+}
@@ -0,0 +1,127 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package config_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zauberhaus/config"
+	"go.yaml.in/yaml/v3"
+)
+
+type SecretTestConfig struct {
+	Password config.Secret[string]
+	Pin      config.Secret[int]
+	Key      string `secret:"true"`
+	User     string `default:"admin"`
+}
+
+func TestSecret(t *testing.T) {
+	s := config.NewSecret("s3cr3t")
+
+	assert.Equal(t, "s3cr3t", s.Value())
+	assert.Equal(t, "******", s.String())
+	assert.Equal(t, "******", fmt.Sprintf("%v", s))
+	assert.Equal(t, "******", fmt.Sprintf("%s", s))
+	assert.Equal(t, "******", fmt.Sprintf("%#v", s))
+	assert.Equal(t, "******", fmt.Sprintf("%q", s))
+
+	data, err := json.Marshal(s)
+	require.NoError(t, err)
+	assert.JSONEq(t, `"******"`, string(data))
+
+	data, err = yaml.Marshal(map[string]any{"password": s})
+	require.NoError(t, err)
+	assert.Equal(t, "password: '******'\n", string(data))
+
+	data, err = s.MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, "******", string(data))
+
+	var buf bytes.Buffer
+	slog.New(slog.NewTextHandler(&buf, nil)).Info("login", "password", s)
+	assert.Contains(t, buf.String(), "password=******")
+	assert.NotContains(t, buf.String(), "s3cr3t")
+}
+
+func TestSecret_Load(t *testing.T) {
+	tempDir := t.TempDir()
+	require.NoError(t, os.Chdir(tempDir))
+
+	t.Run("yaml", func(t *testing.T) {
+		file := filepath.Join(tempDir, "secret.yaml")
+		require.NoError(t, os.WriteFile(file, []byte("password: s3cr3t\npin: 1234\n"), 0644))
+
+		cfg, _, err := config.Load[*SecretTestConfig](config.WithFile(file))
+		require.NoError(t, err)
+		assert.Equal(t, "s3cr3t", cfg.Password.Value())
+		assert.Equal(t, 1234, cfg.Pin.Value())
+	})
+
+	t.Run("json", func(t *testing.T) {
+		file := filepath.Join(tempDir, "secret.json")
+		require.NoError(t, os.WriteFile(file, []byte(`{"password": "s3cr3t", "pin": 1234}`), 0644))
+
+		cfg, _, err := config.Load[*SecretTestConfig](config.WithFile(file))
+		require.NoError(t, err)
+		assert.Equal(t, "s3cr3t", cfg.Password.Value())
+		assert.Equal(t, 1234, cfg.Pin.Value())
+	})
+
+	t.Run("toml", func(t *testing.T) {
+		file := filepath.Join(tempDir, "secret.toml")
+		require.NoError(t, os.WriteFile(file, []byte("password = \"s3cr3t\"\n"), 0644))
+
+		cfg, _, err := config.Load[*SecretTestConfig](config.WithFile(file))
+		require.NoError(t, err)
+		assert.Equal(t, "s3cr3t", cfg.Password.Value())
+	})
+
+	t.Run("env", func(t *testing.T) {
+		t.Setenv("SECRET_PASSWORD", "s3cr3t")
+		t.Setenv("SECRET_PIN", "1234")
+
+		cfg, _, err := config.Load[*SecretTestConfig](config.WithName("secret"))
+		require.NoError(t, err)
+		assert.Equal(t, "s3cr3t", cfg.Password.Value())
+		assert.Equal(t, 1234, cfg.Pin.Value())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Setenv("SECRET_PIN", "abc")
+
+		_, _, err := config.Load[*SecretTestConfig](config.WithName("secret"))
+		assert.Error(t, err)
+	})
+}
+
+func TestSecret_Report(t *testing.T) {
+	tempDir := t.TempDir()
+	require.NoError(t, os.Chdir(tempDir))
+
+	file := filepath.Join(tempDir, "secret.yaml")
+	require.NoError(t, os.WriteFile(file, []byte("password: s3cr3t\nkey: k1\n"), 0644))
+
+	t.Setenv("SECRET_KEY", "k2")
+
+	_, r, err := config.LoadWithReport[*SecretTestConfig](config.WithFile(file), config.WithName("secret"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "******", r["key"].Origin.Value)
+	assert.Equal(t, "******", r["key"].Overridden[0].Value)
+	assert.Equal(t, "admin", r["user"].Origin.Value)
+	assert.NotContains(t, r.String(), "s3cr3t")
+	assert.NotContains(t, r.String(), "k1")
+	assert.NotContains(t, r.String(), "k2")
+}
@@ -0,0 +1,95 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package config
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+)
+
+// SignatureExt is appended to the name of a config file for the name of its
+// detached signature, like config.yaml.sig.
+const SignatureExt = ".sig"
+
+// SignatureError is returned if the signature of a config file is missing
+// or not valid for any of the trusted keys. Without a path no config file
+// was found at all.
+type SignatureError struct {
+	Path    string
+	Missing bool
+}
+
+func (e *SignatureError) Error() string {
+	if e.Path == "" {
+		return "missing signature: no config file found"
+	}
+
+	if e.Missing {
+		return fmt.Sprintf("missing signature: '%s'", e.Path)
+	}
+
+	return fmt.Sprintf("invalid signature: '%s'", e.Path)
+}
+
+// SignFile signs the file with the private key and writes the base64
+// encoded signature to <name>.sig.
+func SignFile(name string, key ed25519.PrivateKey) error {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return err
+	}
+
+	sig := base64.StdEncoding.EncodeToString(ed25519.Sign(key, data))
+
+	return os.WriteFile(name+SignatureExt, []byte(sig+"\n"), 0644)
+}
+
+// VerifyFile checks the signature <name>.sig of the file against the
+// trusted public keys.
+func VerifyFile(name string, keys ...ed25519.PublicKey) error {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return err
+	}
+
+	return verify(&ConfigOptions{PublicKeys: keys}, name, data)
+}
+
+// verify checks the detached signature of a config file.
+func verify(o *ConfigOptions, name string, data []byte) error {
+	// the signature can't be forged, only its size and path are checked
+	tmp := *o
+	tmp.PublicKeys = nil
+	tmp.PermissionPolicy = IgnorePermissions
+	tmp.MaxFileSize = 1024
+
+	txt, err := readFile(&tmp, name+SignatureExt)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return &SignatureError{Path: name, Missing: true}
+		}
+
+		return err
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(txt)))
+	if err != nil || len(sig) != ed25519.SignatureSize {
+		return &SignatureError{Path: name}
+	}
+
+	for _, key := range o.PublicKeys {
+		if len(key) == ed25519.PublicKeySize && ed25519.Verify(key, data, sig) {
+			return nil
+		}
+	}
+
+	return &SignatureError{Path: name}
+}
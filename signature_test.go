@@ -0,0 +1,119 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package config_test
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zauberhaus/config"
+)
+
+func TestLoad_Signature(t *testing.T) {
+	tempDir := t.TempDir()
+	require.NoError(t, os.Chdir(tempDir))
+
+	pub, priv, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	other, otherPriv, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	file := filepath.Join(tempDir, "config.yaml")
+	require.NoError(t, os.WriteFile(file, []byte("host: signed.host.com\nserver: !include server.yaml\n"), 0644))
+	require.NoError(t, config.SignFile(file, priv))
+
+	server := filepath.Join(tempDir, "server.yaml")
+	require.NoError(t, os.WriteFile(server, []byte("name: signed\n"), 0644))
+	require.NoError(t, config.SignFile(server, priv))
+
+	assert.FileExists(t, file+".sig")
+	require.NoError(t, config.VerifyFile(file, pub))
+
+	t.Run("valid", func(t *testing.T) {
+		cfg, _, err := config.Load[*TestLayeredConfig](config.WithFile(file), config.WithPublicKeys(other, pub))
+		require.NoError(t, err)
+		assert.Equal(t, "signed.host.com", cfg.Host)
+		assert.Equal(t, "signed", cfg.Server.Name)
+	})
+
+	t.Run("untrusted key", func(t *testing.T) {
+		_, _, err := config.Load[*TestLayeredConfig](config.WithFile(file), config.WithPublicKeys(other))
+
+		var se *config.SignatureError
+		require.True(t, errors.As(err, &se))
+		assert.False(t, se.Missing)
+		assert.EqualError(t, err, "invalid signature: '"+file+"'")
+	})
+
+	t.Run("tampered", func(t *testing.T) {
+		tampered := filepath.Join(tempDir, "tampered.yaml")
+		require.NoError(t, os.WriteFile(tampered, []byte("host: signed.host.com\n"), 0644))
+		require.NoError(t, config.SignFile(tampered, priv))
+		require.NoError(t, os.WriteFile(tampered, []byte("host: evil.host.com\n"), 0644))
+
+		_, _, err := config.Load[*TestLayeredConfig](config.WithFile(tampered), config.WithPublicKeys(pub))
+		assert.EqualError(t, err, "invalid signature: '"+tampered+"'")
+		assert.Error(t, config.VerifyFile(tampered, pub))
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		unsigned := filepath.Join(tempDir, "unsigned.yaml")
+		require.NoError(t, os.WriteFile(unsigned, []byte("host: unsigned.host.com\n"), 0644))
+
+		_, _, err := config.Load[*TestLayeredConfig](config.WithFile(unsigned), config.WithPublicKeys(pub))
+
+		var se *config.SignatureError
+		require.True(t, errors.As(err, &se))
+		assert.True(t, se.Missing)
+		assert.EqualError(t, err, "missing signature: '"+unsigned+"'")
+
+		cfg, _, err := config.Load[*TestLayeredConfig](config.WithFile(unsigned))
+		require.NoError(t, err)
+		assert.Equal(t, "unsigned.host.com", cfg.Host)
+	})
+
+	t.Run("unsigned include", func(t *testing.T) {
+		main := filepath.Join(tempDir, "main.yaml")
+		require.NoError(t, os.WriteFile(main, []byte("server: !include other.yaml\n"), 0644))
+		require.NoError(t, config.SignFile(main, priv))
+		require.NoError(t, os.WriteFile(filepath.Join(tempDir, "other.yaml"), []byte("name: other\n"), 0644))
+		require.NoError(t, config.SignFile(filepath.Join(tempDir, "other.yaml"), otherPriv))
+
+		_, _, err := config.Load[*TestLayeredConfig](config.WithFile(main), config.WithPublicKeys(pub))
+		assert.ErrorContains(t, err, "invalid signature: ")
+	})
+
+	t.Run("no file", func(t *testing.T) {
+		require.NoError(t, os.Chdir(t.TempDir()))
+		defer func() { require.NoError(t, os.Chdir(tempDir)) }()
+
+		_, _, err := config.Load[*TestLayeredConfig](config.WithName("signed-app"), config.WithPublicKeys(pub))
+
+		var se *config.SignatureError
+		require.True(t, errors.As(err, &se))
+		assert.True(t, se.Missing)
+		assert.EqualError(t, err, "missing signature: no config file found")
+
+		cfg, _, err := config.Load[*TestLayeredConfig](config.WithName("signed-app"))
+		require.NoError(t, err)
+		assert.Equal(t, "localhost", cfg.Host)
+	})
+
+	t.Run("corrupt", func(t *testing.T) {
+		corrupt := filepath.Join(tempDir, "corrupt.yaml")
+		require.NoError(t, os.WriteFile(corrupt, []byte("host: corrupt.host.com\n"), 0644))
+		require.NoError(t, os.WriteFile(corrupt+".sig", []byte("not base64!"), 0644))
+
+		_, _, err := config.Load[*TestLayeredConfig](config.WithFile(corrupt), config.WithPublicKeys(pub))
+		assert.EqualError(t, err, "invalid signature: '"+corrupt+"'")
+	})
+}
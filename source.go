@@ -0,0 +1,72 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package config
+
+import (
+	"context"
+	"maps"
+	"slices"
+
+	"github.com/zauberhaus/config/pkg/index"
+	"github.com/zauberhaus/config/pkg/merge"
+	"github.com/zauberhaus/lookup"
+)
+
+// Source is a custom provider of config values. The name is used as stage
+// in the precedence and as origin in the report.
+type Source interface {
+	Name() string
+	Apply(ctx context.Context, target any, idx index.Index) error
+}
+
+type strictKey struct{}
+
+// IsStrict returns true if the config is loaded in strict mode, sources
+// should return an error for unknown keys then.
+func IsStrict(ctx context.Context) bool {
+	val, _ := ctx.Value(strictKey{}).(bool)
+	return val
+}
+
+type mapSource struct {
+	name   string
+	values map[string]any
+}
+
+// NewMapSource returns a source which sets the values of a map by their path
+// like server.port. Unknown paths are ignored unless in strict mode.
+func NewMapSource(name string, values map[string]any) Source {
+	return &mapSource{
+		name:   name,
+		values: values,
+	}
+}
+
+func (s *mapSource) Name() string {
+	return s.name
+}
+
+func (s *mapSource) Apply(ctx context.Context, target any, idx index.Index) error {
+	keys := slices.Sorted(maps.Keys(s.values))
+
+	for _, k := range keys {
+		strategy := merge.Default
+		if item, ok := idx.LookupPath(k); ok {
+			strategy = item.Merge
+		}
+
+		_, err := merge.Set(target, k, s.values[k], strategy)
+		if err != nil {
+			if _, ok := err.(*lookup.NotFoundError); ok && !IsStrict(ctx) {
+				continue
+			}
+
+			return err
+		}
+	}
+
+	return nil
+}
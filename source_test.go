@@ -0,0 +1,119 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package config_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zauberhaus/config"
+	"github.com/zauberhaus/config/pkg/index"
+	"github.com/zauberhaus/lookup"
+)
+
+type ctxKey struct{}
+
+type testSource struct {
+	name   string
+	strict bool
+	ctx    any
+	err    error
+}
+
+func (s *testSource) Name() string {
+	return s.name
+}
+
+func (s *testSource) Apply(ctx context.Context, target any, idx index.Index) error {
+	s.strict = config.IsStrict(ctx)
+	s.ctx = ctx.Value(ctxKey{})
+
+	if s.err != nil {
+		return s.err
+	}
+
+	if _, ok := idx.Find("LEVEL"); !ok {
+		return errors.New("level not in index")
+	}
+
+	_, err := lookup.Set(target, "level", "debug")
+	return err
+}
+
+func TestLoad_Source(t *testing.T) {
+	require.NoError(t, os.Chdir(t.TempDir()))
+
+	file := filepath.Join(t.TempDir(), "source.yaml")
+	require.NoError(t, os.WriteFile(file, []byte("host: file.host\nport: 2000\nlevel: warn\n"), 0644))
+
+	t.Setenv("SOURCE_PORT", "3000")
+
+	kv := config.NewMapSource("kv", map[string]any{
+		"host": "kv.host",
+		"port": 4000,
+	})
+
+	options := []config.Option{config.WithFile(file), config.WithName("source")}
+
+	t.Run("default precedence", func(t *testing.T) {
+		src := &testSource{name: "custom"}
+		ctx := context.WithValue(context.Background(), ctxKey{}, "value")
+
+		cfg, r, err := config.LoadWithReport[*PrecedenceConfig](append(options,
+			config.WithSource(kv, src), config.WithContext(ctx), config.Strict)...)
+		require.NoError(t, err)
+
+		assert.Equal(t, "kv.host", cfg.Host)
+		assert.Equal(t, 3000, cfg.Port)
+		assert.Equal(t, "debug", cfg.Level)
+		assert.True(t, src.strict)
+		assert.Equal(t, "value", src.ctx)
+
+		assert.Equal(t, config.Origin{Kind: config.SourceOrigin, Name: "kv", Value: "kv.host"}, r["host"].Origin)
+		assert.Equal(t, config.Origin{Kind: config.SourceOrigin, Name: "custom", Value: "debug"}, r["level"].Origin)
+		assert.Equal(t, "source kv", r["port"].Overridden[2].String())
+	})
+
+	t.Run("with precedence", func(t *testing.T) {
+		cfg, _, err := config.Load[*PrecedenceConfig](append(options,
+			config.WithSource(kv),
+			config.WithPrecedence(config.FileStage, config.EnvStage, "kv"))...)
+		require.NoError(t, err)
+		assert.Equal(t, "kv.host", cfg.Host)
+		assert.Equal(t, 4000, cfg.Port)
+	})
+
+	t.Run("strict", func(t *testing.T) {
+		unknown := config.NewMapSource("unknown", map[string]any{"unknown": "value", "host": "kv.host"})
+
+		cfg, _, err := config.Load[*PrecedenceConfig](append(options, config.WithSource(unknown))...)
+		require.NoError(t, err)
+		assert.Equal(t, "kv.host", cfg.Host)
+
+		_, _, err = config.Load[*PrecedenceConfig](append(options, config.WithSource(unknown), config.Strict)...)
+		assert.EqualError(t, err, "source unknown: field not found: unknown")
+	})
+
+	t.Run("error", func(t *testing.T) {
+		src := &testSource{name: "custom", err: errors.New("connection refused")}
+
+		_, _, err := config.Load[*PrecedenceConfig](append(options, config.WithSource(src))...)
+		assert.EqualError(t, err, "source custom: connection refused")
+	})
+
+	t.Run("invalid name", func(t *testing.T) {
+		_, _, err := config.Load[*PrecedenceConfig](append(options, config.WithSource(&testSource{name: "env"}))...)
+		assert.EqualError(t, err, "invalid source name: 'env' is a builtin stage")
+
+		_, _, err = config.Load[*PrecedenceConfig](append(options, config.WithSource(kv, kv))...)
+		assert.EqualError(t, err, "invalid source name: duplicate source 'kv'")
+	})
+}
@@ -15,4 +15,5 @@ const (
 	UnknownFileType FileType = iota
 	JSON
 	YAML
+	TOML
 )
@@ -0,0 +1,226 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package config
+
+import (
+	"context"
+	"crypto/sha256"
+	"io"
+	"os"
+	"slices"
+	"time"
+)
+
+const (
+	DefaultPollInterval = time.Second
+	DefaultDebounce     = 100 * time.Millisecond
+)
+
+// Watch loads the config and reloads it whenever one of the loaded files
+// or the drop-in directory changes. fn is called with the new value or with
+// the previous value and the error if the reload failed. The files are
+// polled until the context is done.
+func Watch[P ~*T, T any](ctx context.Context, fn func(cfg P, err error), options ...Option) (P, error) {
+	o := &ConfigOptions{}
+	for _, opt := range options {
+		opt.Set(o)
+	}
+
+	cfg, files, _, err := load[T](options...)
+	if err != nil {
+		return nil, err
+	}
+
+	w := newWatcher(o, files)
+	current := cfg
+
+	go w.run(ctx, func() ([]configFile, bool) {
+		tmp, files, _, err := load[T](options...)
+		if err != nil {
+			fn(current, err)
+			return nil, false
+		}
+
+		current = tmp
+		fn(current, nil)
+
+		return files, true
+	})
+
+	return cfg, nil
+}
+
+type fileState struct {
+	modTime time.Time
+	size    int64
+	exists  bool
+	hash    [sha256.Size]byte
+}
+
+type watcher struct {
+	interval time.Duration
+	debounce time.Duration
+	dir      string
+	sigs     bool
+	options  *ConfigOptions
+	names    []string
+	state    map[string]fileState
+}
+
+func newWatcher(o *ConfigOptions, files []configFile) *watcher {
+	w := &watcher{
+		interval: o.PollInterval,
+		debounce: o.Debounce,
+		dir:      o.DropInDir,
+		sigs:     len(o.PublicKeys) > 0,
+		options:  o,
+	}
+
+	if w.interval <= 0 {
+		w.interval = DefaultPollInterval
+	}
+
+	if w.debounce <= 0 {
+		w.debounce = DefaultDebounce
+	}
+
+	w.update(files)
+
+	return w
+}
+
+// update sets the files to watch and their current state.
+func (w *watcher) update(files []configFile) {
+	w.names = w.names[:0]
+
+	for _, f := range files {
+		w.names = append(w.names, f.Includes...)
+
+		// in-memory data can't change
+		if f.read == nil {
+			w.names = append(w.names, f.Name)
+		}
+	}
+
+	if w.sigs {
+		for _, name := range slices.Clone(w.names) {
+			w.names = append(w.names, name+SignatureExt)
+		}
+	}
+
+	w.state = w.stat()
+}
+
+func (w *watcher) stat() map[string]fileState {
+	state := map[string]fileState{}
+
+	for _, name := range w.names {
+		state[name] = w.statFile(name)
+	}
+
+	if w.dir != "" {
+		entries, err := readDir(w.options, w.dir)
+		if err == nil {
+			for _, e := range entries {
+				name := joinPath(w.options, w.dir, e.Name())
+				if _, ok := state[name]; !ok {
+					state[name] = w.statFile(name)
+				}
+			}
+		}
+	}
+
+	return state
+}
+
+func (w *watcher) changed() bool {
+	state := w.stat()
+
+	if len(state) != len(w.state) {
+		return true
+	}
+
+	for k, v := range state {
+		if old, ok := w.state[k]; !ok || old != v {
+			return true
+		}
+	}
+
+	return false
+}
+
+// run polls the files and calls reload after the debounce time without
+// further changes. The files returned by reload are watched afterwards.
+func (w *watcher) run(ctx context.Context, reload func() ([]configFile, bool)) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	timer := time.NewTimer(w.debounce)
+	timer.Stop()
+
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if w.changed() {
+				w.state = w.stat()
+				timer.Reset(w.debounce)
+			}
+		case <-timer.C:
+			if files, ok := reload(); ok {
+				w.update(files)
+			}
+		}
+	}
+}
+
+func (w *watcher) statFile(name string) fileState {
+	fi, err := statFile(w.options, name)
+	if err != nil {
+		return fileState{}
+	}
+
+	state := fileState{
+		modTime: fi.ModTime(),
+		size:    fi.Size(),
+		exists:  true,
+	}
+
+	// an edit of the same size within the resolution of the mtime only
+	// changes the content
+	if fi.Mode().IsRegular() {
+		state.hash = w.hashFile(name)
+	}
+
+	return state
+}
+
+func (w *watcher) hashFile(name string) [sha256.Size]byte {
+	var f io.ReadCloser
+	var err error
+
+	if w.options.FS != nil {
+		f, err = openFS(w.options, name)
+	} else {
+		f, err = os.Open(name)
+	}
+
+	if err != nil {
+		return [sha256.Size]byte{}
+	}
+
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, io.LimitReader(f, maxFileSize(w.options)+1)); err != nil {
+		return [sha256.Size]byte{}
+	}
+
+	return [sha256.Size]byte(h.Sum(nil))
+}
@@ -0,0 +1,134 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package config_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zauberhaus/config"
+)
+
+type watchResult struct {
+	cfg *TestLayeredConfig
+	err error
+}
+
+func TestWatch(t *testing.T) {
+	require.NoError(t, os.Chdir(t.TempDir()))
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "watch.yaml")
+	require.NoError(t, os.WriteFile(file, []byte("host: first.host\n"), 0644))
+
+	dropIns := filepath.Join(dir, "conf.d")
+	require.NoError(t, os.Mkdir(dropIns, 0755))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	results := make(chan watchResult, 10)
+
+	cfg, err := config.Watch[*TestLayeredConfig](ctx, func(cfg *TestLayeredConfig, err error) {
+		results <- watchResult{cfg, err}
+	}, config.WithFile(file), config.WithDropInDir(dropIns), config.WithPollInterval(10*time.Millisecond), config.WithDebounce(30*time.Millisecond))
+	require.NoError(t, err)
+	assert.Equal(t, "first.host", cfg.Host)
+
+	next := func(t *testing.T) watchResult {
+		select {
+		case r := <-results:
+			return r
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "timeout")
+			return watchResult{}
+		}
+	}
+
+	t.Run("change", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(file, []byte("host: second.host\n"), 0644))
+
+		r := next(t)
+		require.NoError(t, r.err)
+		assert.Equal(t, "second.host", r.cfg.Host)
+	})
+
+	t.Run("invalid file keeps value", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(file, []byte("host: [invalid\n"), 0644))
+
+		r := next(t)
+		assert.Error(t, r.err)
+		assert.Equal(t, "second.host", r.cfg.Host)
+
+		require.NoError(t, os.WriteFile(file, []byte("host: third.host\n"), 0644))
+
+		r = next(t)
+		require.NoError(t, r.err)
+		assert.Equal(t, "third.host", r.cfg.Host)
+	})
+
+	t.Run("new drop-in", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(filepath.Join(dropIns, "10-port.yaml"), []byte("port: 9000\n"), 0644))
+
+		r := next(t)
+		require.NoError(t, r.err)
+		assert.Equal(t, "third.host", r.cfg.Host)
+		assert.Equal(t, 9000, r.cfg.Port)
+	})
+
+	t.Run("debounce", func(t *testing.T) {
+		for i := range 3 {
+			require.NoError(t, os.WriteFile(file, []byte("host: host"+string(rune('a'+i))+".com\n"), 0644))
+			time.Sleep(5 * time.Millisecond)
+		}
+
+		r := next(t)
+		require.NoError(t, r.err)
+		assert.Equal(t, "hostc.com", r.cfg.Host)
+
+		select {
+		case r := <-results:
+			assert.Fail(t, "unexpected reload", "%v", r)
+		case <-time.After(100 * time.Millisecond):
+		}
+	})
+
+	t.Run("same size and mtime", func(t *testing.T) {
+		fi, err := os.Stat(file)
+		require.NoError(t, err)
+
+		require.NoError(t, os.WriteFile(file, []byte("host: hostd.com\n"), 0644))
+		require.NoError(t, os.Chtimes(file, fi.ModTime(), fi.ModTime()))
+
+		r := next(t)
+		require.NoError(t, r.err)
+		assert.Equal(t, "hostd.com", r.cfg.Host)
+	})
+
+	t.Run("stop", func(t *testing.T) {
+		cancel()
+		time.Sleep(20 * time.Millisecond)
+
+		require.NoError(t, os.WriteFile(file, []byte("host: stopped.host\n"), 0644))
+
+		select {
+		case r := <-results:
+			assert.Fail(t, "unexpected reload", "%v", r)
+		case <-time.After(100 * time.Millisecond):
+		}
+	})
+
+	t.Run("load error", func(t *testing.T) {
+		_, err := config.Watch[*TestLayeredConfig](context.Background(), func(cfg *TestLayeredConfig, err error) {},
+			config.WithFile(filepath.Join(dir, "missing.yaml")))
+		assert.True(t, os.IsNotExist(err))
+	})
+}